@@ -0,0 +1,118 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const (
+	filenameFlag = "filename"
+	forceFlag    = "force"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update resources from manifests",
+	Long: fmt.Sprintf(`Create or update resources from manifests.
+
+The resource type is selected based on the manifest kind: %s,
+%s, %s or %s.
+
+Resources that already exist with the same name and the same spec are left
+unchanged. Since the controller does not support updating resources in
+place, a resource with a different spec is replaced only when --%s is set.`,
+		connectionKind, appConnectionKind, accessPolicyKind, networkSLAKind, forceFlag),
+	RunE: apply,
+}
+
+func apply(cmd *cobra.Command, _ []string) error {
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(cmd.Flag(filenameFlag).Value.String(), recursive)
+	if err != nil {
+		return err
+	}
+	force, err := cmd.Flags().GetBool(forceFlag)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	conn, err := clients.connection()
+	if err != nil {
+		return err
+	}
+
+	return processDocuments(documents, func(doc document) error {
+		resource, err := newManifestResource(doc.Viper)
+		if err != nil {
+			return err
+		}
+		result, err := applyManifest(conn, resource, force)
+		if err != nil {
+			return fmt.Errorf("could not apply %s %q: %v", resource.kind(), resource.name(), err)
+		}
+		fmt.Printf("%s/%s %s\n", resource.kind(), resource.name(), result)
+		return nil
+	})
+}
+
+func applyManifest(conn *grpc.ClientConn, m manifestResource, force bool) (string, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	id, live, err := m.find(ctx, conn)
+	if err != nil {
+		return "", fmt.Errorf("could not look up existing %s: %v", m.kind(), err)
+	}
+	result := "created"
+	if id != "" {
+		if equalManifest(m, live) {
+			return "unchanged", nil
+		}
+		if !force {
+			return "", fmt.Errorf("already exists with a different spec, use --%s to replace it", forceFlag)
+		}
+		logger.Infof("deleting %s %s before recreating it", m.kind(), id)
+		if err := m.delete(ctx, conn, id); err != nil {
+			return "", fmt.Errorf("could not delete existing %s: %v", m.kind(), err)
+		}
+		result = "replaced"
+	}
+	logger.Infof("sending create %s request", m.kind())
+	status, err := m.create(ctx, conn)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s (status: %s)", result, status.String()), nil
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().StringP(filenameFlag, "f", "", "Manifest file, directory of manifests or - for standard input")
+	_ = applyCmd.MarkFlagRequired(filenameFlag)
+	applyCmd.Flags().BoolP(recursiveFlag, "R", false, "Process the directory used in -f recursively")
+	applyCmd.Flags().Bool(forceFlag, false, "Replace existing resources whose spec differs from the manifest")
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/app-net-interface/catalyst-sdwan-app-client/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	sessionExpiryFlag   = controllersFlag + ".session_expiry"
+	sessionLifetimeFlag = controllersFlag + ".session_lifetime"
+
+	// defaultSessionLifetime is the default session lifetime of vManage.
+	defaultSessionLifetime = 24 * time.Hour
+
+	serverInfoPath = "/dataservice/client/server"
+	logoutPath     = "/logout"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the vManage session",
+	Long: `Manage the vManage session stored in the config by generate-token.
+
+The session expiry is estimated from the time of the login and
+controllers.sdwan.session_lifetime, which defaults to the 24 hour session
+lifetime of vManage.`,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the user, controller and expiry of the stored session",
+	Args:  cobra.NoArgs,
+	RunE:  authStatus,
+}
+
+var authRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Log in again and store the new session",
+	Long: fmt.Sprintf(`Log in again and store the new session.
+
+The password is read like in generate-token: from standard input with --%s,
+from a file with --%s, from the %v environment variable or from the
+terminal.`, passwordStdinFlag, passwordFileFlag, environmentVariableFlag),
+	Args: cobra.NoArgs,
+	RunE: authRefresh,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Invalidate the stored session and remove it from the config",
+	Args:  cobra.NoArgs,
+	RunE:  authLogout,
+}
+
+// sessionInfo describes the vManage session stored in the config.
+type sessionInfo struct {
+	User       string
+	Controller string
+	Expiry     time.Time
+	// LoggedIn reports whether a session is stored in the config.
+	LoggedIn bool
+	// Valid reports whether vManage accepted the session.
+	Valid bool
+}
+
+func authStatus(_ *cobra.Command, _ []string) error {
+	if viper.GetString(bearerTokenFlag) != "" {
+		fmt.Printf("Controller: %s\n", viper.GetString(urlFlag))
+		fmt.Println("Credentials: bearer token")
+		return nil
+	}
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	info, err := checkSession(ctx)
+	if err != nil {
+		return err
+	}
+	printSessionInfo(os.Stdout, info, time.Now())
+	return nil
+}
+
+func authRefresh(cmd *cobra.Command, _ []string) error {
+	username, err := loginUsername(cmd)
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(cmd)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	token, sessionID, err := vManageLogin(ctx, username, password)
+	if err != nil {
+		return err
+	}
+	values := sessionValues(token, sessionID, time.Now())
+	values[usernameFlag] = username
+	if err := saveConfig(values); err != nil {
+		return err
+	}
+	fmt.Printf("Session refreshed, expires at %s\n", values[sessionExpiryFlag])
+	return nil
+}
+
+func authLogout(_ *cobra.Command, _ []string) error {
+	if viper.GetString(sessionIDFlag) == "" {
+		fmt.Println("Not logged in")
+		return nil
+	}
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	if err := logoutSession(ctx); err != nil {
+		return err
+	}
+	if err := saveConfig(map[string]interface{}{
+		tokenFlag:         "",
+		sessionIDFlag:     "",
+		sessionExpiryFlag: "",
+	}); err != nil {
+		return err
+	}
+	fmt.Println("Logged out")
+	return nil
+}
+
+// sessionValues returns the config values storing a new session.
+func sessionValues(token, sessionID string, loggedIn time.Time) map[string]interface{} {
+	lifetime := viper.GetDuration(sessionLifetimeFlag)
+	if lifetime <= 0 {
+		lifetime = defaultSessionLifetime
+	}
+	return map[string]interface{}{
+		tokenFlag:         token,
+		sessionIDFlag:     sessionID,
+		sessionExpiryFlag: loggedIn.Add(lifetime).UTC().Format(time.RFC3339),
+	}
+}
+
+// sessionClient returns a vManage client using the session stored in the
+// config.
+func sessionClient() (*client.Client, *http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create cookie jar: %v", err)
+	}
+	controllerURL := viper.GetString(urlFlag)
+	u, err := url.Parse(controllerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse url: %v", err)
+	}
+	jar.SetCookies(u, []*http.Cookie{{Name: sessionCookie, Value: viper.GetString(sessionIDFlag)}})
+	httpClient := vManageHTTPClient(jar)
+	c := client.NewClient(controllerURL, httpClient, logger)
+	c.Token = viper.GetString(tokenFlag)
+	return c, httpClient, nil
+}
+
+// checkSession asks vManage for the user of the stored session. A session
+// which vManage does not accept is reported as invalid rather than as an
+// error.
+func checkSession(ctx context.Context) (*sessionInfo, error) {
+	info := &sessionInfo{
+		User:       viper.GetString(usernameFlag),
+		Controller: viper.GetString(urlFlag),
+	}
+	if expiry := viper.GetString(sessionExpiryFlag); expiry != "" {
+		t, err := time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %v", sessionExpiryFlag, err)
+		}
+		info.Expiry = t
+	}
+	if viper.GetString(sessionIDFlag) == "" {
+		return info, nil
+	}
+	info.LoggedIn = true
+	c, _, err := sessionClient()
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.GetRequest(ctx, serverInfoPath)
+	if err != nil {
+		var loginErr *client.LoginError
+		if errors.As(err, &loginErr) {
+			return info, nil
+		}
+		return nil, fmt.Errorf("could not check session: %v", err)
+	}
+	var server struct {
+		Data struct {
+			User string `json:"user"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, fmt.Errorf("could not decode server info: %v", err)
+	}
+	info.Valid = true
+	if server.Data.User != "" {
+		info.User = server.Data.User
+	}
+	return info, nil
+}
+
+// logoutSession invalidates the stored session in vManage. vManage answers
+// with a redirect to the login page, so the response body is ignored.
+func logoutSession(ctx context.Context) error {
+	_, httpClient, err := sessionClient()
+	if err != nil {
+		return err
+	}
+	logoutURL := fmt.Sprintf("%s%s?nocache=%d", viper.GetString(urlFlag), logoutPath, time.Now().Unix())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, logoutURL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("X-XSRF-TOKEN", viper.GetString(tokenFlag))
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("could not log out: %v", err)
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Infof("failed to close response body: %v", err)
+		}
+	}()
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("could not log out: invalid status code: %d", response.StatusCode)
+	}
+	return nil
+}
+
+func printSessionInfo(w io.Writer, info *sessionInfo, now time.Time) {
+	user := info.User
+	if user == "" {
+		user = "<unknown>"
+	}
+	fmt.Fprintf(w, "User: %s\n", user)
+	fmt.Fprintf(w, "Controller: %s\n", info.Controller)
+	switch {
+	case info.Valid:
+		fmt.Fprintln(w, "Session: valid")
+	case !info.LoggedIn:
+		fmt.Fprintln(w, "Session: none, run generate-token to log in")
+		return
+	default:
+		fmt.Fprintln(w, "Session: invalid, run auth refresh to log in again")
+	}
+	if info.Expiry.IsZero() {
+		fmt.Fprintln(w, "Expires: unknown")
+		return
+	}
+	left := info.Expiry.Sub(now).Round(time.Minute)
+	if left <= 0 {
+		fmt.Fprintf(w, "Expires: %s (expired)\n", info.Expiry.Format(time.RFC3339))
+		return
+	}
+	fmt.Fprintf(w, "Expires: %s (in %s)\n", info.Expiry.Format(time.RFC3339), left)
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authRefreshCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	addPasswordFlags(authRefreshCmd)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const loginPage = "<html><body>login</body></html>"
+
+// fakeVManage implements the login, server info and logout endpoints of
+// vManage for a single user.
+type fakeVManage struct {
+	mu       sync.Mutex
+	username string
+	password string
+	sessions map[string]string // session ID to token
+	next     int
+}
+
+func newFakeVManage(t *testing.T, username, password string) *httptest.Server {
+	f := &fakeVManage{username: username, password: password, sessions: map[string]string{}}
+	s := httptest.NewServer(f.handler())
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (f *fakeVManage) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/j_security_check", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("j_username") != f.username || r.FormValue("j_password") != f.password {
+			fmt.Fprint(w, loginPage)
+			return
+		}
+		f.mu.Lock()
+		f.next++
+		id := fmt.Sprintf("session-%d", f.next)
+		f.sessions[id] = fmt.Sprintf("token-%d", f.next)
+		f.mu.Unlock()
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: id, Path: "/"})
+	})
+	mux.HandleFunc("/dataservice/client/token", func(w http.ResponseWriter, r *http.Request) {
+		token, ok := f.session(r, false)
+		if !ok {
+			fmt.Fprint(w, loginPage)
+			return
+		}
+		fmt.Fprintf(w, `{"token": %q}`, token)
+	})
+	mux.HandleFunc(serverInfoPath, func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := f.session(r, true); !ok {
+			fmt.Fprint(w, loginPage)
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"user": %q, "userMode": "tenant"}}`, f.username)
+	})
+	mux.HandleFunc(logoutPath, func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			f.mu.Lock()
+			delete(f.sessions, cookie.Value)
+			f.mu.Unlock()
+		}
+		fmt.Fprint(w, loginPage)
+	})
+	return mux
+}
+
+// session returns the token of the session in the request cookie. When
+// checkToken is set, the request must also carry the token.
+func (f *fakeVManage) session(r *http.Request, checkToken bool) (string, bool) {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.sessions[cookie.Value]
+	if !ok || checkToken && r.Header.Get("X-XSRF-TOKEN") != token {
+		return "", false
+	}
+	return token, true
+}
+
+func TestAuthSession(t *testing.T) {
+	t.Cleanup(resetConfig)
+	server := newFakeVManage(t, "admin", "secret")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`globals:
+  grpc_url: `+server.URL+`
+controllers:
+  sdwan:
+    session_lifetime: 30m
+`), 0o600))
+	require.NoError(t, initConfig(path))
+	ctx := context.Background()
+
+	info, err := checkSession(ctx)
+	require.NoError(t, err)
+	require.False(t, info.LoggedIn)
+
+	_, _, err = loginToVManage(ctx, "admin", "wrong")
+	require.Error(t, err)
+
+	now := time.Now()
+	token, sessionID, err := loginToVManage(ctx, "admin", "secret")
+	require.NoError(t, err)
+	require.Equal(t, "token-1", token)
+	require.Equal(t, "session-1", sessionID)
+	require.NoError(t, saveConfig(sessionValues(token, sessionID, now)))
+
+	info, err = checkSession(ctx)
+	require.NoError(t, err)
+	require.True(t, info.Valid)
+	require.Equal(t, "admin", info.User)
+	require.Equal(t, server.URL, info.Controller)
+	require.Equal(t, now.Add(30*time.Minute).Truncate(time.Second).UTC(), info.Expiry)
+
+	out := &bytes.Buffer{}
+	printSessionInfo(out, info, info.Expiry.Add(-90*time.Second))
+	require.Equal(t, fmt.Sprintf(`User: admin
+Controller: %s
+Session: valid
+Expires: %s (in 2m0s)
+`, server.URL, info.Expiry.Format(time.RFC3339)), out.String())
+
+	require.NoError(t, logoutSession(ctx))
+	info, err = checkSession(ctx)
+	require.NoError(t, err)
+	require.True(t, info.LoggedIn)
+	require.False(t, info.Valid)
+
+	file, err := readConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "session-1", file.GetString(sessionIDFlag))
+	require.Equal(t, info.Expiry.Format(time.RFC3339), file.GetString(sessionExpiryFlag))
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// skipConfigAnnotation marks commands which run without the configuration
+// file and the controller.
+const skipConfigAnnotation = "awi/skip-config"
+
+// helpWithoutFlagAnnotation names the flag without which the command only
+// prints its help, and so runs without the configuration file.
+const helpWithoutFlagAnnotation = "awi/help-without-flag"
+
+// dialController connects to the controller, replaced in tests.
+var dialController = func() (*grpc.ClientConn, error) {
+	return getGRPCClient()
+}
+
+// clients is the client factory of the running command, set up by the
+// PersistentPreRunE of rootCmd.
+var clients *clientFactory
+
+// clientFactory owns the connection to the controller shared by all calls
+// of a command and creates the typed clients of its services. The
+// connection is established on first use, so commands which do not call
+// the controller never dial it.
+type clientFactory struct {
+	dial func() (*grpc.ClientConn, error)
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+	err  error
+}
+
+func newClientFactory(dial func() (*grpc.ClientConn, error)) *clientFactory {
+	return &clientFactory{dial: dial}
+}
+
+// connection returns the shared connection, dialing it on first use.
+func (f *clientFactory) connection() (*grpc.ClientConn, error) {
+	if f == nil {
+		return nil, fmt.Errorf("the configuration is not initialized")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn == nil && f.err == nil {
+		f.conn, f.err = f.dial()
+	}
+	return f.conn, f.err
+}
+
+// close closes the shared connection if it was established.
+func (f *clientFactory) close() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil {
+		connClose(f.conn)
+		f.conn = nil
+	}
+}
+
+func (f *clientFactory) cloud() (awi.CloudClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return awi.NewCloudClient(conn), nil
+}
+
+func (f *clientFactory) connectionController() (awi.ConnectionControllerClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return awi.NewConnectionControllerClient(conn), nil
+}
+
+func (f *clientFactory) appConnectionController() (awi.AppConnectionControllerClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return awi.NewAppConnectionControllerClient(conn), nil
+}
+
+func (f *clientFactory) securityPolicy() (awi.SecurityPolicyServiceClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return awi.NewSecurityPolicyServiceClient(conn), nil
+}
+
+func (f *clientFactory) networkSLA() (awi.NetworkSLAServiceClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return awi.NewNetworkSLAServiceClient(conn), nil
+}
+
+func (f *clientFactory) cloudProvider() (infrapb.CloudProviderServiceClient, error) {
+	conn, err := f.connection()
+	if err != nil {
+		return nil, err
+	}
+	return infrapb.NewCloudProviderServiceClient(conn), nil
+}
+
+// setUpClients reads the configuration file and sets up the client
+// factory before any command runs.
+func setUpClients(cmd *cobra.Command, _ []string) error {
+	if skipConfig(cmd) {
+		return nil
+	}
+	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
+		return fmt.Errorf("could not initialize config: %v", err)
+	}
+	clients = newClientFactory(dialController)
+	return nil
+}
+
+// skipConfig reports whether the command runs without the configuration
+// file: help, shell completion, commands marked with skipConfigAnnotation
+// and commands printing their help as the flag named by
+// helpWithoutFlagAnnotation is not set.
+func skipConfig(cmd *cobra.Command) bool {
+	if cmd.Name() == "help" || cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return true
+	}
+	if flag := cmd.Annotations[helpWithoutFlagAnnotation]; flag != "" && !cmd.Flags().Changed(flag) {
+		return true
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "completion" && c.Parent() == rootCmd {
+			return true
+		}
+		if c.Annotations[skipConfigAnnotation] != "" {
+			return true
+		}
+	}
+	return false
+}
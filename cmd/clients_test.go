@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestClientFactorySharesConnection(t *testing.T) {
+	dials := 0
+	f := newClientFactory(func() (*grpc.ClientConn, error) {
+		dials++
+		return grpc.Dial("passthrough:///controller", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	})
+	_, err := f.cloud()
+	require.NoError(t, err)
+	_, err = f.connectionController()
+	require.NoError(t, err)
+	_, err = f.cloudProvider()
+	require.NoError(t, err)
+	require.Equal(t, 1, dials)
+
+	conn, err := f.connection()
+	require.NoError(t, err)
+	f.close()
+	require.Equal(t, connectivity.Shutdown, conn.GetState())
+}
+
+func TestClientFactoryDialError(t *testing.T) {
+	dials := 0
+	f := newClientFactory(func() (*grpc.ClientConn, error) {
+		dials++
+		return nil, errors.New("could not connect to grpc server: context deadline exceeded")
+	})
+	_, err := f.securityPolicy()
+	require.EqualError(t, err, "could not connect to grpc server: context deadline exceeded")
+	_, err = f.networkSLA()
+	require.Error(t, err)
+	require.Equal(t, 1, dials)
+	f.close()
+
+	var missing *clientFactory
+	_, err = missing.appConnectionController()
+	require.EqualError(t, err, "the configuration is not initialized")
+}
+
+func TestSkipConfig(t *testing.T) {
+	tests := []struct {
+		args []string
+		skip bool
+	}{
+		{args: []string{"validate"}, skip: true},
+		{args: []string{"dev", "fake-server"}, skip: true},
+		{args: []string{"list", "connection"}, skip: false},
+		{args: []string{"config", "get-contexts"}, skip: false},
+		{args: []string{"delete"}, skip: true},
+	}
+	for _, tt := range tests {
+		cmd, _, err := rootCmd.Find(tt.args)
+		require.NoError(t, err)
+		require.Equal(t, tt.skip, skipConfig(cmd), tt.args)
+	}
+
+	t.Cleanup(func() { resetFlags(rootCmd) })
+	require.NoError(t, deleteCmd.Flags().Set(filenameFlag, "manifests.yaml"))
+	require.False(t, skipConfig(deleteCmd))
+}
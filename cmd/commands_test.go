@@ -0,0 +1,622 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/app-net-interface/awi-cli/fake"
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+const (
+	connectionExample   = "../examples/internetworkdomainconnection/vpc-to-vpc-based-on-ids.yaml"
+	accessPolicyExample = "../examples/accesspolicy/access-policy.yaml"
+	networkSLAExample   = "../examples/networksla/network-sla.yaml"
+
+	appConnectionDocument = `apiVersion: awi.app-net-interface.io/v1alpha1
+kind: InterNetworkDomainAppConnection
+metadata:
+  name: development-db-to-staging-db
+spec:
+  appConnection:
+    controller: AWI
+    networkDomainConnection:
+      selector:
+        matchName: AWI development to staging
+    metadata:
+      name: development-db-to-staging-db
+    from:
+      endpoint:
+        selector:
+          matchLabels:
+            environment: development
+            app_type: database
+    to:
+      endpoint:
+        selector:
+          matchLabels:
+            environment: staging
+            app_type: database
+`
+)
+
+// fakeNow is the time the fake controller uses for timestamps.
+var fakeNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// cliHarness runs rootCmd against a fake controller served over bufconn.
+type cliHarness struct {
+	t      *testing.T
+	config string
+}
+
+func newCLIHarness(t *testing.T) *cliHarness {
+	listener := bufconn.Listen(1 << 20)
+	controller := fake.NewController()
+	controller.Now = func() time.Time { return fakeNow }
+	server := fake.NewServer(controller)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`globals:
+  grpc_url: bufnet
+  use_proxy: false
+  log_level: warn
+`), 0o600))
+
+	dial := dialController
+	dialController = func() (*grpc.ClientConn, error) {
+		return getGRPCClient(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	}
+	t.Cleanup(func() {
+		dialController = dial
+		rootCmd.SetArgs(nil)
+		resetFlags(rootCmd)
+		resetConfig()
+	})
+	return &cliHarness{t: t, config: config}
+}
+
+// run executes the command line with the harness config and returns what
+// it wrote to stdout and stderr.
+func (h *cliHarness) run(args ...string) (string, string, error) {
+	h.t.Helper()
+	resetFlags(rootCmd)
+	resetConfig()
+	log := logger
+	stdout := captureFile(h.t, &os.Stdout)
+	stderr := captureFile(h.t, &os.Stderr)
+	prettyprint.SetOutput(os.Stdout)
+
+	rootCmd.SetArgs(append([]string{"--" + configFlag, h.config}, args...))
+	err := rootCmd.Execute()
+	clients.close()
+	clients = nil
+	logger = log
+
+	out, errOut := stdout(), stderr()
+	prettyprint.SetOutput(os.Stdout)
+	return out, errOut, err
+}
+
+// captureFile redirects the file to a pipe until the returned function is
+// called, which restores it and returns everything written in between.
+func captureFile(t *testing.T, f **os.File) func() string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	original := *f
+	*f = w
+	var buffer bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&buffer, r)
+		close(done)
+	}()
+	return func() string {
+		*f = original
+		_ = w.Close()
+		<-done
+		_ = r.Close()
+		return buffer.String()
+	}
+}
+
+// resetFlags restores the default values of the flags of the command and
+// its subcommands, which cobra keeps between executions.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if value, ok := f.Value.(pflag.SliceValue); ok {
+			_ = value.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+func TestConnectionCommands(t *testing.T) {
+	h := newCLIHarness(t)
+
+	stdout, _, err := h.run("create", "connection", "--"+connectionConfigFlag, connectionExample)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "connection-1")
+	require.Contains(t, stdout, "Status: SUCCESS")
+
+	stdout, _, err = h.run("list", "connection")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "connection-1")
+	require.Contains(t, stdout, "AWI staging to development")
+	require.Contains(t, stdout, "awi-staging")
+	require.Contains(t, stdout, fakeNow.Format(time.RFC3339))
+	require.Contains(t, stdout, "STATUS    AGE")
+
+	stdout, _, err = h.run("list", "connection", "--"+timestampsFlag, "relative")
+	require.NoError(t, err)
+	require.NotContains(t, stdout, fakeNow.Format(time.RFC3339))
+	require.Contains(t, stdout, " ago")
+
+	_, stderr, err := h.run("list", "connection", "--"+timestampsFlag, "unix")
+	require.Error(t, err)
+	require.Contains(t, stderr, `unsupported timestamps format "unix"`)
+
+	stdout, _, err = h.run("list", "connection", "-o", "json")
+	require.NoError(t, err)
+	var connections []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &connections))
+	require.Len(t, connections, 1)
+	require.Equal(t, "connection-1", connections[0]["id"])
+	require.Equal(t, "SUCCESS", connections[0]["status"])
+	require.Equal(t, "AWI staging to development", connections[0]["metadata"].(map[string]interface{})["name"])
+	require.NotContains(t, connections[0], "state")
+
+	stdout, _, err = h.run("list", "connection", "-o", "yaml")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "kind: InterNetworkDomainConnection\n")
+	require.Contains(t, stdout, "  id: connection-1\n")
+	require.Contains(t, stdout, "networkDomain:")
+	manifest := filepath.Join(t.TempDir(), "connection.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(stdout), 0o600))
+	_, _, err = h.run("validate", "--"+filenameFlag, manifest)
+	require.NoError(t, err)
+
+	stdout, _, err = h.run("list", "connection", "-o", "csv")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "ID,NAME,SRC_NAME,")
+	require.Contains(t, stdout, "connection-1,AWI staging to development,awi-staging,VPC,aws,")
+
+	stdout, _, err = h.run("list", "connection", "-o", "markdown")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "| ID | NAME | SRC_NAME |")
+	require.Contains(t, stdout, "| connection-1 | AWI staging to development | awi-staging |")
+
+	stdout, _, err = h.run("get", "connection", "connection-1")
+	require.NoError(t, err)
+	var connection map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &connection))
+	require.Equal(t, "connection-1", connection["id"])
+	require.Contains(t, connection, "source")
+	require.Contains(t, connection, "destination")
+
+	stdout, _, err = h.run("get", "connection", "AWI staging to development", "-o", "wide")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "SRC_ID")
+	require.Contains(t, stdout, "connection-1")
+
+	_, stderr, err = h.run("get", "connection", "connection-9")
+	require.Error(t, err)
+	require.Contains(t, stderr, `connection "connection-9" not found`)
+
+	stdout, _, err = h.run("wait", "connection", "connection-1", "--"+waitTimeoutFlag, "5s")
+	require.NoError(t, err)
+	require.Equal(t, "connection-1: Status: SUCCESS\n", stdout)
+
+	stdout, _, err = h.run("delete", "connection", "connection-1")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Status: SUCCESS")
+
+	stdout, _, err = h.run("list", "connection")
+	require.NoError(t, err)
+	require.NotContains(t, stdout, "connection-1")
+
+	_, stderr, err = h.run("delete", "connection", "connection-1")
+	require.Error(t, err)
+	require.Contains(t, stderr, `connection "connection-1" not found`)
+
+	// The YAML output can be used to create the connection again.
+	stdout, _, err = h.run("create", "connection", "--"+connectionConfigFlag, manifest)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "connection-2")
+	stdout, _, err = h.run("list", "connection")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "AWI staging to development")
+	require.Contains(t, stdout, "awi-staging")
+}
+
+func TestAppConnectionCommands(t *testing.T) {
+	h := newCLIHarness(t)
+	manifest := filepath.Join(t.TempDir(), "app-connection.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(appConnectionDocument), 0o600))
+
+	stdout, _, err := h.run("create", "app-connection", "--"+connectionConfigFlag, manifest)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "app-connection-1")
+	require.Contains(t, stdout, "Status: SUCCESS")
+
+	stdout, _, err = h.run("get", "app-connection", "--"+idFlag, "app-connection-1")
+	require.NoError(t, err)
+	var appConnection struct {
+		ID                  string `json:"id"`
+		AppConnectionConfig struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"appConnectionConfig"`
+		SourceMatched struct {
+			MatchedInstances []struct {
+				Name string `json:"Name"`
+			} `json:"matchedInstances"`
+		} `json:"sourceMatched"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &appConnection))
+	require.Equal(t, "app-connection-1", appConnection.ID)
+	require.Equal(t, "development-db-to-staging-db", appConnection.AppConnectionConfig.Metadata.Name)
+	require.Len(t, appConnection.SourceMatched.MatchedInstances, 1)
+	require.Equal(t, "development-db-1", appConnection.SourceMatched.MatchedInstances[0].Name)
+
+	stdout, _, err = h.run("get", "app-connection", "app-connection-1", "-o", "table")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "NETWORK_DOMAIN_CONNECTION_NAME")
+	require.Contains(t, stdout, "development-db-to-staging-db")
+
+	stdout, _, err = h.run("list", "app-connection")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "development-db-to-staging-db")
+	require.Contains(t, stdout, "AWI development to staging")
+
+	stdout, _, err = h.run("get", "app-connection", "--"+idFlag, "app-connection-1", "-o", "yaml")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "kind: InterNetworkDomainAppConnection\n")
+	require.Contains(t, stdout, "    networkDomainConnection:\n")
+	require.Contains(t, stdout, "  networkDomainConnectionName: AWI development to staging\n")
+	require.NoError(t, os.WriteFile(manifest, []byte(stdout), 0o600))
+	_, _, err = h.run("validate", "--"+filenameFlag, manifest)
+	require.NoError(t, err)
+
+	stdout, _, err = h.run("get", "app-connection", "--"+idFlag, "app-connection-1",
+		"-o", "go-template={{.id}} {{.appConnectionConfig.metadata.name}}")
+	require.NoError(t, err)
+	require.Equal(t, "app-connection-1 development-db-to-staging-db", stdout)
+
+	_, stderr, err := h.run("get", "app-connection", "--"+idFlag, "app-connection-1", "-o", "xml")
+	require.Error(t, err)
+	require.Contains(t, stderr, `unsupported output format "xml"`)
+
+	_, _, err = h.run("delete", "app-connection", "app-connection-1")
+	require.NoError(t, err)
+
+	_, stderr, err = h.run("get", "app-connection", "--"+idFlag, "app-connection-1")
+	require.Error(t, err)
+	require.Contains(t, stderr, `app connection "app-connection-1" not found`)
+}
+
+func TestPolicyCommands(t *testing.T) {
+	h := newCLIHarness(t)
+
+	stdout, _, err := h.run("create", "access-policy", "--"+connectionConfigFlag, accessPolicyExample)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Status: SUCCESS")
+	stdout, _, err = h.run("create", "network-sla", "--"+connectionConfigFlag, networkSLAExample)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Status: SUCCESS")
+
+	_, stderr, err := h.run("create", "access-policy", "--"+connectionConfigFlag, accessPolicyExample)
+	require.Error(t, err)
+	require.Contains(t, stderr, `access policy "access-policy-1" already exists`)
+
+	stdout, _, err = h.run("list", "access-policy")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "access-policy-1")
+	stdout, _, err = h.run("list", "network-sla")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "example-network-sla")
+	require.Contains(t, stdout, "Customer-Facing")
+	table, _, err := h.run("list", "network-sla", "-o", "table")
+	require.NoError(t, err)
+	require.Equal(t, stdout, table)
+
+	stdout, _, err = h.run("list", "access-policy", "-o", "yaml")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "kind: accessPolicy\n")
+	require.Contains(t, stdout, "accessProtocols:")
+	stdout, _, err = h.run("list", "network-sla", "-o", "yaml")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "kind: networkSLA\n")
+	require.Contains(t, stdout, "trafficProfile:")
+
+	stdout, _, err = h.run("get", "access-policy", "access-policy-1", "-o", "yaml")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "kind: accessPolicy\n")
+	require.Contains(t, stdout, "accessProtocols:")
+	stdout, _, err = h.run("get", "network-sla", "example-network-sla", "-o", "table")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "example-network-sla")
+	require.Contains(t, stdout, "BANDWIDTH[Mbps]")
+	stdout, _, err = h.run("get", "network-sla", "example-network-sla", "-o", "csv")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "example-network-sla,")
+	_, stderr, err = h.run("get", "network-sla", "missing-sla")
+	require.Error(t, err)
+	require.Contains(t, stderr, `network SLA "missing-sla" not found`)
+
+	_, _, err = h.run("delete", "access-policy", "access-policy-1")
+	require.NoError(t, err)
+	_, _, err = h.run("delete", "network-sla", "example-network-sla")
+	require.NoError(t, err)
+
+	stdout, _, err = h.run("list", "access-policy")
+	require.NoError(t, err)
+	require.NotContains(t, stdout, "access-policy-1")
+	stdout, _, err = h.run("list", "network-sla")
+	require.NoError(t, err)
+	require.NotContains(t, stdout, "example-network-sla")
+}
+
+func TestApplyCommands(t *testing.T) {
+	h := newCLIHarness(t)
+	dir := t.TempDir()
+	for name, example := range map[string]string{
+		"connection.yaml":    connectionExample,
+		"access-policy.yaml": accessPolicyExample,
+	} {
+		b, err := os.ReadFile(example)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), b, 0o600))
+	}
+
+	stdout, _, err := h.run("apply", "-f", dir)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "accessPolicy/access-policy-1 created (status: SUCCESS)\n")
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development created (status: SUCCESS)\n")
+
+	stdout, _, err = h.run("apply", "-f", dir)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "accessPolicy/access-policy-1 unchanged\n")
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development unchanged\n")
+
+	// A modified manifest is only replaced with --force. The other
+	// documents are still applied when one of them fails.
+	policy := filepath.Join(dir, "access-policy.yaml")
+	b, err := os.ReadFile(policy)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(policy, []byte(strings.Replace(string(b), "accessType: allow", "accessType: deny", 1)), 0o600))
+
+	stdout, stderr, err := h.run("apply", "-f", dir)
+	require.Error(t, err)
+	require.Contains(t, stderr, `could not apply accessPolicy "access-policy-1": already exists with a different spec, use --force to replace it`)
+	require.Contains(t, stderr, "1 of 2 documents failed")
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development unchanged\n")
+
+	stdout, _, err = h.run("apply", "-f", dir, "--"+forceFlag)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "accessPolicy/access-policy-1 replaced (status: SUCCESS)\n")
+
+	stdout, _, err = h.run("get", "access-policy", "access-policy-1", "-o", "jsonpath={.accessType}")
+	require.NoError(t, err)
+	require.Equal(t, "deny", stdout)
+}
+
+func TestDeleteFromManifests(t *testing.T) {
+	h := newCLIHarness(t)
+	stdout, _, err := h.run("--"+configFlag, filepath.Join(t.TempDir(), "missing.yaml"), "delete")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Delete resources either by ID")
+
+	var documents []string
+	for _, example := range []string{accessPolicyExample, connectionExample} {
+		b, err := os.ReadFile(example)
+		require.NoError(t, err)
+		documents = append(documents, string(b))
+	}
+	manifest := filepath.Join(t.TempDir(), "resources.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(strings.Join(documents, "\n---\n")), 0o600))
+	_, _, err = h.run("apply", "-f", manifest)
+	require.NoError(t, err)
+
+	// Documents are deleted in reverse order.
+	stdout, _, err = h.run("delete", "-f", manifest)
+	require.NoError(t, err)
+	connection := strings.Index(stdout, "InterNetworkDomainConnection/AWI staging to development deleted\n")
+	policy := strings.Index(stdout, "accessPolicy/access-policy-1 deleted\n")
+	require.GreaterOrEqual(t, connection, 0)
+	require.Greater(t, policy, connection)
+
+	_, stderr, err := h.run("delete", "-f", manifest)
+	require.Error(t, err)
+	require.Contains(t, stderr, `accessPolicy "access-policy-1" not found`)
+	require.Contains(t, stderr, "2 of 2 documents failed")
+
+	stdout, _, err = h.run("delete", "-f", manifest, "--"+ignoreNotFoundFlag)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "accessPolicy/access-policy-1 not found\n")
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development not found\n")
+}
+
+func TestPlanCommands(t *testing.T) {
+	h := newCLIHarness(t)
+
+	stdout, _, err := h.run("plan", "-f", connectionExample)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development would be created\n")
+	require.Contains(t, stdout, "  source:\n    VPC aws vpc-0fe7d06b468142a7e (awi-staging)\n")
+	require.Contains(t, stdout, "subnet-0c1d2e3f4a5b6c7d8   staging-db   10.1.1.0/24")
+	require.Contains(t, stdout, "i-0a1b2c3d4e5f60718   staging-db-1   10.1.1.10")
+
+	// --dry-run prints the plan without creating anything.
+	stdout, _, err = h.run("create", "connection", "--"+connectionConfigFlag, connectionExample, "--"+dryRunFlag)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development would be created (dry run)\n")
+	require.Contains(t, stdout, "staging-db-1")
+	stdout, _, err = h.run("list", "connection", "-o", "json")
+	require.NoError(t, err)
+	require.Equal(t, "[]", strings.TrimSpace(stdout))
+
+	manifest := filepath.Join(t.TempDir(), "app-connection.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(appConnectionDocument), 0o600))
+	stdout, _, err = h.run("create", "app-connection", "--"+connectionConfigFlag, manifest, "--"+dryRunFlag)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "InterNetworkDomainAppConnection/development-db-to-staging-db would be created (dry run)\n")
+	require.Contains(t, stdout, "  network domain connection: no connection named AWI development to staging\n")
+	require.Contains(t, stdout, "i-0b2c3d4e5f6071829   development-db-1")
+	require.Contains(t, stdout, "i-0a1b2c3d4e5f60718   staging-db-1")
+	stdout, _, err = h.run("list", "app-connection", "-o", "json")
+	require.NoError(t, err)
+	require.Equal(t, "[]", strings.TrimSpace(stdout))
+
+	_, _, err = h.run("create", "connection", "--"+connectionConfigFlag, connectionExample)
+	require.NoError(t, err)
+	stdout, _, err = h.run("plan", "-f", connectionExample)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "InterNetworkDomainConnection/AWI staging to development unchanged\n")
+}
+
+func TestDescribeCommands(t *testing.T) {
+	h := newCLIHarness(t)
+	document := strings.NewReplacer(
+		"matchName: AWI development to staging", "matchName: AWI staging to development",
+		"    from:", `    accessPolicy:
+      selector:
+        matchName:
+          name: access-policy-1
+    networkPolicy:
+      selector:
+        matchName: example-network-sla
+    from:`,
+	).Replace(appConnectionDocument)
+	manifest := filepath.Join(t.TempDir(), "app-connection.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(document), 0o600))
+	for _, args := range [][]string{
+		{"create", "access-policy", "--" + connectionConfigFlag, accessPolicyExample},
+		{"create", "network-sla", "--" + connectionConfigFlag, networkSLAExample},
+		{"create", "connection", "--" + connectionConfigFlag, connectionExample},
+		{"create", "app-connection", "--" + connectionConfigFlag, manifest},
+	} {
+		_, _, err := h.run(args...)
+		require.NoError(t, err)
+	}
+
+	stdout, _, err := h.run("describe", "app-connection", "app-connection-1")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Name:        development-db-to-staging-db\n")
+	require.Contains(t, stdout, "Network Domain Connection:\n  Name:         AWI staging to development\n  ID:           connection-1\n")
+	require.Contains(t, stdout, "  Selector:  matchName access-policy-1\n")
+	require.Contains(t, stdout, "  access-policy-1   allow")
+	require.Contains(t, stdout, "  example-network-sla   100.0")
+	require.Contains(t, stdout, "development-db-1")
+	require.Contains(t, stdout, "staging-db-1")
+
+	stdout, _, err = h.run("describe", "connection", "AWI staging to development")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "ID:        connection-1\n")
+	require.Contains(t, stdout, "  Network Domain:  VPC aws vpc-0fe7d06b468142a7e (awi-staging)\n")
+	require.Contains(t, stdout, "staging-db-1")
+	require.Contains(t, stdout, "Access Policies:\n  <none>\n")
+	require.Contains(t, stdout, "App Connections:\n  ID                 NAME                           STATUS\n")
+	require.Contains(t, stdout, "  app-connection-1   development-db-to-staging-db   SUCCESS\n")
+
+	_, stderr, err := h.run("describe", "connection", "connection-9")
+	require.Error(t, err)
+	require.Contains(t, stderr, `connection "connection-9" not found`)
+}
+
+func TestInventoryCommands(t *testing.T) {
+	h := newCLIHarness(t)
+
+	stdout, _, err := h.run("list", "network-domains")
+	require.NoError(t, err)
+	for _, name := range []string{"corporate", "guest", "awi-staging", "awi-development", "awi-infra", "awi-sandbox"} {
+		require.Contains(t, stdout, name)
+	}
+
+	stdout, _, err = h.run("list", "vpc", "--"+cloudFlag, "aws", "--"+regionFlag, "us-east-1")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "vpc-0fe7d06b468142a7e")
+	require.Contains(t, stdout, "awi-infra")
+	require.NotContains(t, stdout, "awi-development")
+	require.NotContains(t, stdout, "awi-sandbox")
+
+	stdout, _, err = h.run("list", "vpc", "--"+cloudFlag, "aws", "-o", "custom-columns=NAME:.name,ENV:.labels.env")
+	require.NoError(t, err)
+	require.Equal(t, `NAME              ENV
+awi-staging       staging
+awi-development   development
+awi-infra         <none>
+
+`, stdout)
+
+	stdout, _, err = h.run("list", "vpc", "--"+cloudFlag, "gcp", "-o", `jsonpath={range [*]}{.id}{"\n"}{end}`)
+	require.NoError(t, err)
+	require.Equal(t, "4815162342108\n", stdout)
+
+	_, stderr, err := h.run("list", "vpc", "--"+cloudFlag, "aws", "-o", "jsonpath={.name")
+	require.Error(t, err)
+	require.Contains(t, stderr, "invalid jsonpath template")
+
+	stdout, _, err = h.run("list", "instance", "--"+cloudFlag, "aws", "-o", "wide", "--"+sortByFlag, "name", "--"+noHeadersFlag)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "i-0b2c3d4e5f6071829   development-db-1"), lines[0])
+	require.True(t, strings.HasSuffix(lines[0], "running   app_type=database,environment=development"), lines[0])
+	require.Contains(t, lines[1], "staging-db-1")
+
+	_, stderr, err = h.run("list", "vpc", "--"+cloudFlag, "aws", "--"+sortByFlag, "size")
+	require.Error(t, err)
+	require.Contains(t, stderr, `cannot sort by unknown column "size"`)
+
+	_, stderr, err = h.run("list", "vpc")
+	require.Error(t, err)
+	require.Contains(t, stderr, `required flag(s) "cloud" not set`)
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+const (
+	contextFlag       = "context"
+	contextEnv        = "AWI_CONTEXT"
+	contextsKey       = "contexts"
+	currentContextKey = "current_context"
+
+	grpcURLFlag                 = "grpc-url"
+	useProxyContextFlag         = "use-proxy"
+	secureConnectionContextFlag = "secure-connection"
+	tokenContextFlag            = "token"
+	sessionIDContextFlag        = "session-id"
+	tlsContextFlag              = "tls"
+	tlsCAFileContextFlag        = "tls-ca-file"
+	tlsServerNameContextFlag    = "tls-server-name"
+	tlsCertFileContextFlag      = "tls-cert-file"
+	tlsKeyFileContextFlag       = "tls-key-file"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage contexts in the configuration file",
+	Long: fmt.Sprintf(`Manage contexts in the configuration file.
+
+A context is a named set of settings stored under %q in the configuration
+file. The settings of the selected context override the top level settings,
+so a single file can hold the controllers of several environments, e.g.
+
+  %s: staging
+  %s:
+    staging:
+      globals:
+        grpc_url: staging.example.com:443
+      controllers:
+        sdwan:
+          token: ...
+
+The context is selected with --%s, the %s environment variable or
+"awi config use-context", in this order.`,
+		contextsKey, currentContextKey, contextsKey, contextFlag, contextEnv),
+}
+
+var getContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List the contexts in the configuration file",
+	Args:  cobra.NoArgs,
+	RunE:  getContexts,
+}
+
+var useContextCmd = &cobra.Command{
+	Use:   "use-context <name>",
+	Short: "Set the current context in the configuration file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  useContext,
+}
+
+var setContextCmd = &cobra.Command{
+	Use:   "set-context <name>",
+	Short: "Create a context or update its settings",
+	Args:  cobra.ExactArgs(1),
+	RunE:  setContext,
+}
+
+type contextDisplay struct {
+	Current  string
+	Name     string
+	GRPCURL  string
+	UseProxy string
+}
+
+func getContexts(_ *cobra.Command, _ []string) error {
+	file, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	current := activeContext()
+	names := make([]string, 0)
+	for name := range file.GetStringMap(contextsKey) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	displays := make([]contextDisplay, 0, len(names))
+	for _, name := range names {
+		display := contextDisplay{
+			Name:     name,
+			GRPCURL:  contextSetting(file, name, urlFlag),
+			UseProxy: contextSetting(file, name, useProxyFlag),
+		}
+		if name == current {
+			display.Current = "*"
+		}
+		displays = append(displays, display)
+	}
+	return prettyprint.PrintData(displays, []prettyprint.Display{
+		{Name: "Current", Display: "CURRENT"},
+		{Name: "Name", Display: "NAME"},
+		{Name: "GRPCURL", Display: "GRPC_URL"},
+		{Name: "UseProxy", Display: "USE_PROXY"},
+	}, "")
+}
+
+// contextSetting returns the value of the key in the context or the top
+// level value if the context does not override it.
+func contextSetting(file *viper.Viper, name, key string) string {
+	if file.IsSet(contextKey(name, key)) {
+		return file.GetString(contextKey(name, key))
+	}
+	return file.GetString(key)
+}
+
+func useContext(_ *cobra.Command, args []string) error {
+	name := args[0]
+	if !viper.IsSet(contextsKey + "." + name) {
+		return fmt.Errorf("context %q not found in %s", name, viper.ConfigFileUsed())
+	}
+	if err := writeConfig(map[string]interface{}{currentContextKey: name}); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to context %q\n", name)
+	return nil
+}
+
+func setContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := checkContextName(name); err != nil {
+		return err
+	}
+	settings := map[string]string{
+		grpcURLFlag:                 urlFlag,
+		useProxyContextFlag:         useProxyFlag,
+		secureConnectionContextFlag: secureConnectionFlag,
+		tokenContextFlag:            tokenFlag,
+		sessionIDContextFlag:        sessionIDFlag,
+		tlsContextFlag:              tlsEnabledFlag,
+		tlsCAFileContextFlag:        tlsCAFileFlag,
+		tlsServerNameContextFlag:    tlsServerNameFlag,
+		tlsCertFileContextFlag:      tlsCertFileFlag,
+		tlsKeyFileContextFlag:       tlsKeyFileFlag,
+	}
+	values := map[string]interface{}{}
+	for flag, key := range settings {
+		f := cmd.Flag(flag)
+		if !f.Changed {
+			continue
+		}
+		if f.Value.Type() == "bool" {
+			values[contextKey(name, key)] = f.Value.String() == "true"
+		} else {
+			values[contextKey(name, key)] = f.Value.String()
+		}
+	}
+	if err := storeContextSecrets(name, values); err != nil {
+		return err
+	}
+	if len(values) == 0 && !viper.IsSet(contextsKey+"."+name) {
+		values[contextsKey+"."+name] = map[string]interface{}{}
+	}
+	if err := writeConfig(values); err != nil {
+		return err
+	}
+	fmt.Printf("Context %q set\n", name)
+	return nil
+}
+
+// storeContextSecrets moves the secrets set for the context to the
+// credential store, if one is configured.
+func storeContextSecrets(name string, values map[string]interface{}) error {
+	if activeCredentialStore == nil {
+		return nil
+	}
+	changed := map[string]string{}
+	for key, secret := range credentialKeys {
+		if value, ok := values[contextKey(name, key)]; ok {
+			changed[secret] = fmt.Sprint(value)
+			delete(values, contextKey(name, key))
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	file, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	credentials := contextSetting(file, name, credentialNameFlag)
+	if credentials == "" {
+		credentials = name
+	}
+	return storeSecrets(credentials, changed)
+}
+
+func checkContextName(name string) error {
+	if name == "" || strings.ContainsAny(name, ". ") {
+		return fmt.Errorf("invalid context name %q, names must not be empty or contain dots and spaces", name)
+	}
+	return nil
+}
+
+func contextKey(name, key string) string {
+	return contextsKey + "." + name + "." + key
+}
+
+// activeContext returns the name of the selected context or an empty
+// string if the top level settings are used.
+func activeContext() string {
+	if name := viper.GetString(contextFlag); name != "" {
+		return name
+	}
+	return viper.GetString(currentContextKey)
+}
+
+// applyContext overrides the top level settings with the settings of the
+// selected context.
+func applyContext() error {
+	name := activeContext()
+	if name == "" {
+		return nil
+	}
+	settings := viper.Sub(contextsKey + "." + name)
+	if settings == nil {
+		return fmt.Errorf("context %q not found in %s", name, viper.ConfigFileUsed())
+	}
+	for _, key := range settings.AllKeys() {
+		viper.Set(key, settings.Get(key))
+	}
+	logger.Debugf("Using context: %s", name)
+	return nil
+}
+
+// saveConfig stores the settings in the configuration file. When a context
+// is selected, the settings are stored in that context. Secrets are moved
+// to the credential store if one is configured.
+func saveConfig(values map[string]interface{}) error {
+	for key, value := range values {
+		viper.Set(key, value)
+	}
+	values, err := saveCredentials(values)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		stored[configKey(key)] = value
+	}
+	return writeConfig(stored)
+}
+
+// configKey returns the key a setting is stored under in the
+// configuration file.
+func configKey(key string) string {
+	if name := activeContext(); name != "" {
+		return contextKey(name, key)
+	}
+	return key
+}
+
+// writeConfig sets the keys in the configuration file as it is on disk,
+// without the overrides of the selected context. Unlike
+// viper.WriteConfig, the rest of the file and its comments are kept.
+func writeConfig(values map[string]interface{}) error {
+	path := viper.ConfigFileUsed()
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not read config: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config: %v", err)
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return fmt.Errorf("could not read config: %v", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := setYAMLValue(doc.Content[0], strings.Split(key, "."), values[key]); err != nil {
+			return fmt.Errorf("could not set %s: %v", key, err)
+		}
+	}
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("could not write config: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("could not write config: %v", err)
+	}
+	return nil
+}
+
+// setYAMLValue sets the value at the path below the mapping node. Keys are
+// matched case-insensitively like viper does, and missing mappings are
+// created.
+func setYAMLValue(node *yaml.Node, path []string, value interface{}) error {
+	if node.Kind != yaml.MappingNode {
+		*node = yaml.Node{Kind: yaml.MappingNode, HeadComment: node.HeadComment, LineComment: node.LineComment}
+	}
+	var child *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, path[0]) {
+			child = node.Content[i+1]
+			break
+		}
+	}
+	if child == nil {
+		child = &yaml.Node{Kind: yaml.MappingNode}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}, child)
+	}
+	if len(path) > 1 {
+		return setYAMLValue(child, path[1:], value)
+	}
+	encoded := &yaml.Node{}
+	if err := encoded.Encode(value); err != nil {
+		return err
+	}
+	encoded.HeadComment, encoded.LineComment, encoded.FootComment = child.HeadComment, child.LineComment, child.FootComment
+	*child = *encoded
+	return nil
+}
+
+// readConfigFile reads the configuration file in use as it is on disk.
+func readConfigFile() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(viper.ConfigFileUsed())
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("could not read config: %v", err)
+	}
+	return v, nil
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(getContextsCmd)
+	configCmd.AddCommand(useContextCmd)
+	configCmd.AddCommand(setContextCmd)
+	setContextCmd.Flags().String(grpcURLFlag, "", "Address of the controller or the proxy")
+	setContextCmd.Flags().Bool(useProxyContextFlag, false, "Call the controller through the proxy")
+	setContextCmd.Flags().Bool(secureConnectionContextFlag, false, "Verify the TLS certificate of vManage")
+	setContextCmd.Flags().String(tokenContextFlag, "", "vManage token")
+	setContextCmd.Flags().String(sessionIDContextFlag, "", "vManage session ID")
+	setContextCmd.Flags().Bool(tlsContextFlag, false, "Use TLS for the gRPC connection")
+	setContextCmd.Flags().String(tlsCAFileContextFlag, "", "CA bundle used to verify the gRPC server")
+	setContextCmd.Flags().String(tlsServerNameContextFlag, "", "Server name expected in the certificate of the gRPC server")
+	setContextCmd.Flags().String(tlsCertFileContextFlag, "", "Client certificate for mutual TLS")
+	setContextCmd.Flags().String(tlsKeyFileContextFlag, "", "Client key for mutual TLS")
+
+	rootCmd.PersistentFlags().String(contextFlag, "", fmt.Sprintf("Context of the configuration file to use, overrides %s", contextEnv))
+	bindContext()
+}
+
+// bindContext makes the --context flag and the environment variable
+// available as the contextFlag key.
+func bindContext() {
+	_ = viper.BindPFlag(contextFlag, rootCmd.PersistentFlags().Lookup(contextFlag))
+	_ = viper.BindEnv(contextFlag, contextEnv)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const contextsConfig = `globals:
+  grpc_url: localhost:80
+  use_proxy: true
+current_context: dev
+contexts:
+  dev:
+    globals:
+      grpc_url: dev.example.com:443
+  prod:
+    globals:
+      grpc_url: prod.example.com:443
+      use_proxy: false
+`
+
+// resetConfig drops all settings, as if no config file was read.
+func resetConfig() {
+	viper.Reset()
+	bindContext()
+	activeCredentialStore = nil
+}
+
+func TestContexts(t *testing.T) {
+	t.Cleanup(resetConfig)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contextsConfig), 0o600))
+
+	require.NoError(t, initConfig(path))
+	require.Equal(t, "dev.example.com:443", viper.GetString(urlFlag))
+	require.True(t, viper.GetBool(useProxyFlag))
+
+	require.NoError(t, saveConfig(map[string]interface{}{tokenFlag: "secret"}))
+	file, err := readConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "secret", file.GetString(contextKey("dev", tokenFlag)))
+	require.False(t, file.IsSet(tokenFlag))
+	require.Equal(t, "localhost:80", file.GetString(urlFlag))
+
+	resetConfig()
+	t.Setenv(contextEnv, "prod")
+	require.NoError(t, initConfig(path))
+	require.Equal(t, "prod.example.com:443", viper.GetString(urlFlag))
+	require.False(t, viper.GetBool(useProxyFlag))
+
+	resetConfig()
+	t.Setenv(contextEnv, "staging")
+	require.EqualError(t, initConfig(path), `context "staging" not found in `+path)
+}
@@ -36,5 +36,15 @@ var createCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(createCmd)
-	createCmd.PersistentFlags().String(connectionConfigFlag, "", "Connection configuration file in YAML format")
+	createCmd.PersistentFlags().String(connectionConfigFlag, "", "Connection configuration file or directory in YAML format, - for standard input")
+	createCmd.PersistentFlags().BoolP(recursiveFlag, "R", false, "Process the directory used in --"+connectionConfigFlag+" recursively")
+}
+
+// readCreateDocuments reads all documents passed to a create subcommand.
+func readCreateDocuments(cmd *cobra.Command) ([]document, error) {
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return nil, err
+	}
+	return readDocuments(cmd.Flag(connectionConfigFlag).Value.String(), recursive)
 }
@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -34,37 +32,37 @@ var createAccessPolicyCmd = &cobra.Command{
 }
 
 func createAccessPolicy(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	AccessPolicyConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
-
-	// Set up a connection to the server.
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-
-	config, err := getAccessControlConfigGRPC(AccessPolicyConfigPath)
+	documents, err := readCreateDocuments(cmd)
 	if err != nil {
 		return fmt.Errorf("could not initialize AccessPolicy config: %v", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if config == nil {
-		return fmt.Errorf("wrong config")
-	}
-	logger.Infof("sending create request")
-	c := awi.NewSecurityPolicyServiceClient(conn)
-	response, err := c.CreateAccessPolicy(ctx, &awi.AccessPolicyCreateRequest{AccessPolicy: config})
+
+	c, err := clients.securityPolicy()
 	if err != nil {
-		return fmt.Errorf("could not create AccessPolicy: %v", err)
+		return err
 	}
-	fmt.Printf("Response: %s\n", response.String())
-	fmt.Printf("Status: %v\n", response.Status.String())
-
-	return nil
+	return processDocuments(documents, func(doc document) error {
+		if err := checkKind(doc, accessPolicyKind); err != nil {
+			return err
+		}
+		config, err := decodeAccessControlConfig(doc.Viper)
+		if err != nil {
+			return fmt.Errorf("could not initialize AccessPolicy config: %v", err)
+		}
+		if config == nil {
+			return fmt.Errorf("wrong config")
+		}
+		ctx, cancel := requestContext()
+		defer cancel()
+		logger.Infof("sending create request")
+		response, err := c.CreateAccessPolicy(ctx, &awi.AccessPolicyCreateRequest{AccessPolicy: config})
+		if err != nil {
+			return fmt.Errorf("could not create AccessPolicy: %v", err)
+		}
+		fmt.Printf("Response: %s\n", response.String())
+		fmt.Printf("Status: %v\n", response.Status.String())
+		return nil
+	})
 }
 
 func init() {
@@ -18,12 +18,9 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
-	awi "github.com/app-net-interface/awi-grpc/pb"
 )
 
 // createAppCmd represents the connect command
@@ -34,40 +31,46 @@ var createAppCmd = &cobra.Command{
 }
 
 func createApp(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	connectionConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
-
-	// Set up a connection to the server.
-	conn, err := getGRPCClient()
+	documents, err := readCreateDocuments(cmd)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not initialize connection config: %v", err)
 	}
-	defer connClose(conn)
-
-	acl, err := getAppConnectionConfigGRPC(connectionConfigPath)
+	onlyPlan, err := cmd.Flags().GetBool(dryRunFlag)
 	if err != nil {
-		return fmt.Errorf("could not initialize connection config: %v", err)
+		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
 
-	if acl == nil {
-		return fmt.Errorf("specify App Connection Request in the config")
-	}
-	logger.Infof("sending create ACL request")
-	cc := awi.NewAppConnectionControllerClient(conn)
-	response, err := cc.ConnectApps(ctx, acl)
+	// Set up a connection to the server.
+	cc, err := clients.appConnectionController()
 	if err != nil {
-		return fmt.Errorf("could not create connection: %v", err)
+		return err
 	}
-	fmt.Printf("Response: %s\n", response.String())
-	fmt.Printf("Status: %v\n", response.Status.String())
-
-	return nil
+	return processDocuments(documents, func(doc document) error {
+		if err := checkKind(doc, appConnectionKind); err != nil {
+			return err
+		}
+		acl, err := decodeAppConnectionConfig(doc.Viper)
+		if err != nil {
+			return fmt.Errorf("could not initialize connection config: %v", err)
+		}
+		if onlyPlan {
+			return dryRun(&appConnectionManifest{appConnection: acl})
+		}
+		ctx, cancel := requestContext()
+		defer cancel()
+		logger.Infof("sending create ACL request")
+		response, err := cc.ConnectApps(ctx, acl)
+		if err != nil {
+			return fmt.Errorf("could not create connection: %v", err)
+		}
+		fmt.Printf("Response: %s\n", response.String())
+		fmt.Printf("Status: %v\n", response.Status.String())
+		return waitAfterCreate(cmd, appConnectionStatus(cc, response.GetAppConnId()))
+	})
 }
 
 func init() {
 	createCmd.AddCommand(createAppCmd)
+	addWaitFlags(createAppCmd)
+	createAppCmd.Flags().Bool(dryRunFlag, false, "Print the resources matched by the selectors without creating the connection")
 }
@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -34,37 +32,34 @@ var createAppPolicyCmd = &cobra.Command{
 }
 
 func createAppPolicy(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	connectionConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
-
-	// Set up a connection to the server.
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-
-	conf, err := getAppConnectionConfigGRPC(connectionConfigPath)
+	documents, err := readCreateDocuments(cmd)
 	if err != nil {
 		return fmt.Errorf("could not initialize connection config: %v", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
 
-	if conf == nil {
-		return fmt.Errorf("specify App Connection Policy in the config")
-	}
-	logger.Infof("sending create AppConnection Policy request")
-	cc := awi.NewAppConnectionControllerClient(conn)
-	response, err := cc.CreateAppConnectionPolicy(ctx, &awi.CreateAppConnectionPolicyRequest{AppConnection: conf})
+	cc, err := clients.appConnectionController()
 	if err != nil {
-		return fmt.Errorf("could not create app connection policy: %v", err)
+		return err
 	}
-	fmt.Printf("ID: %s\n", response.GetId())
-	fmt.Printf("Status: %s\n", response.GetStatus())
-	return nil
+	return processDocuments(documents, func(doc document) error {
+		if err := checkKind(doc, appConnectionKind); err != nil {
+			return err
+		}
+		conf, err := decodeAppConnectionConfig(doc.Viper)
+		if err != nil {
+			return fmt.Errorf("could not initialize connection config: %v", err)
+		}
+		ctx, cancel := requestContext()
+		defer cancel()
+		logger.Infof("sending create AppConnection Policy request")
+		response, err := cc.CreateAppConnectionPolicy(ctx, &awi.CreateAppConnectionPolicyRequest{AppConnection: conf})
+		if err != nil {
+			return fmt.Errorf("could not create app connection policy: %v", err)
+		}
+		fmt.Printf("ID: %s\n", response.GetId())
+		fmt.Printf("Status: %s\n", response.GetStatus())
+		return nil
+	})
 }
 
 func init() {
@@ -18,12 +18,9 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
-	awi "github.com/app-net-interface/awi-grpc/pb"
 )
 
 // createConnectionCmd represents the connect command
@@ -34,39 +31,46 @@ var createConnectionCmd = &cobra.Command{
 }
 
 func createConnection(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
+	documents, err := readCreateDocuments(cmd)
+	if err != nil {
+		return fmt.Errorf("could not initialize connection config: %v", err)
 	}
-	connectionConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
-
-	// Set up a connection to the server.
-	conn, err := getGRPCClient()
+	onlyPlan, err := cmd.Flags().GetBool(dryRunFlag)
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 
-	request, err := getConnectionConfigGRPC(connectionConfigPath)
-	if err != nil {
-		return fmt.Errorf("could not initialize connection config: %v", err)
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if request == nil {
-		return fmt.Errorf("specify either Connection Request or Access Control Request")
-	}
-	logger.Infof("sending create request")
-	c := awi.NewConnectionControllerClient(conn)
-	response, err := c.Connect(ctx, request)
+	// Set up a connection to the server.
+	c, err := clients.connectionController()
 	if err != nil {
-		return fmt.Errorf("could not create connection: %v", err)
+		return err
 	}
-	fmt.Printf("Response: %s\n", response.String())
-	fmt.Printf("Status: %v\n", response.Status.String())
-
-	return nil
+	return processDocuments(documents, func(doc document) error {
+		if err := checkKind(doc, connectionKind); err != nil {
+			return err
+		}
+		request, err := decodeConnectionConfig(doc.Viper)
+		if err != nil {
+			return fmt.Errorf("could not initialize connection config: %v", err)
+		}
+		if onlyPlan {
+			return dryRun(&connectionManifest{request: request})
+		}
+		ctx, cancel := requestContext()
+		defer cancel()
+		logger.Infof("sending create request")
+		response, err := c.Connect(ctx, request)
+		if err != nil {
+			return fmt.Errorf("could not create connection: %v", err)
+		}
+		fmt.Printf("Response: %s\n", response.String())
+		fmt.Printf("Status: %v\n", response.Status.String())
+		return waitAfterCreate(cmd, connectionStatus(c, response.GetConnectionId()))
+	})
 }
 
 func init() {
 	createCmd.AddCommand(createConnectionCmd)
+	addWaitFlags(createConnectionCmd)
+	createConnectionCmd.Flags().Bool(dryRunFlag, false, "Print the resources matched by the selectors without creating the connection")
 }
@@ -18,12 +18,9 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
-	awi "github.com/app-net-interface/awi-grpc/pb"
 )
 
 // createNetworkSLA represents the networkSLA creation
@@ -34,37 +31,37 @@ var createNetworkSLACmd = &cobra.Command{
 }
 
 func createNetworkSLA(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	networkSLAConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
-
-	// Set up a connection to the server.
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-
-	request, err := getNetworkSLAConfigGRPC(networkSLAConfigPath)
+	documents, err := readCreateDocuments(cmd)
 	if err != nil {
 		return fmt.Errorf("could not initialize networkSLA config: %v", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if request == nil {
-		return fmt.Errorf("wrong config")
-	}
-	logger.Infof("sending create request")
-	c := awi.NewNetworkSLAServiceClient(conn)
-	response, err := c.CreateNetworkSLA(ctx, request)
+
+	c, err := clients.networkSLA()
 	if err != nil {
-		return fmt.Errorf("could not create networkSLA: %v", err)
+		return err
 	}
-	fmt.Printf("Response: %s\n", response.String())
-	fmt.Printf("Status: %v\n", response.Status.String())
-
-	return nil
+	return processDocuments(documents, func(doc document) error {
+		if err := checkKind(doc, networkSLAKind); err != nil {
+			return err
+		}
+		request, err := decodeNetworkSLAConfig(doc.Viper)
+		if err != nil {
+			return fmt.Errorf("could not initialize networkSLA config: %v", err)
+		}
+		if request == nil {
+			return fmt.Errorf("wrong config")
+		}
+		ctx, cancel := requestContext()
+		defer cancel()
+		logger.Infof("sending create request")
+		response, err := c.CreateNetworkSLA(ctx, request)
+		if err != nil {
+			return fmt.Errorf("could not create networkSLA: %v", err)
+		}
+		fmt.Printf("Response: %s\n", response.String())
+		fmt.Printf("Status: %v\n", response.Status.String())
+		return nil
+	})
 }
 
 func init() {
@@ -0,0 +1,410 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	credentialsFlag         = controllersFlag + ".credentials"
+	credentialStoreFlag     = credentialsFlag + ".store"
+	credentialFileFlag      = credentialsFlag + ".file"
+	credentialHelperFlag    = credentialsFlag + ".helper"
+	credentialNameFlag      = credentialsFlag + ".name"
+	credentialPassphraseEnv = "AWI_CREDENTIALS_PASSPHRASE"
+
+	fileCredentialStore   = "file"
+	helperCredentialStore = "helper"
+
+	defaultCredentialName = "default"
+	defaultCredentialFile = ".awi/credentials"
+
+	credentialFileVersion = 1
+)
+
+// credentialKeys maps the config keys of secrets to their names in a
+// credential store.
+var credentialKeys = map[string]string{
+	tokenFlag:       "token",
+	sessionIDFlag:   "session_id",
+	bearerTokenFlag: "bearer_token",
+}
+
+// credentialStore keeps the secrets of the config outside of the
+// configuration file, which only holds the name they are stored under.
+type credentialStore interface {
+	// get returns the secrets stored under the name, or nil if there are
+	// none.
+	get(name string) (map[string]string, error)
+	store(name string, secrets map[string]string) error
+	erase(name string) error
+}
+
+// activeCredentialStore is the store configured in the config or nil if
+// secrets are kept in the configuration file.
+var activeCredentialStore credentialStore
+
+// newCredentialStore returns the store configured in the config.
+func newCredentialStore() (credentialStore, error) {
+	switch kind := viper.GetString(credentialStoreFlag); kind {
+	case "":
+		return nil, nil
+	case fileCredentialStore:
+		path := viper.GetString(credentialFileFlag)
+		if path == "" || strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("could not find home directory: %v", err)
+			}
+			if path == "" {
+				path = filepath.Join(home, defaultCredentialFile)
+			} else {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		return &encryptedFileStore{path: path, readPassphrase: readCredentialPassphrase}, nil
+	case helperCredentialStore:
+		command := strings.Fields(viper.GetString(credentialHelperFlag))
+		if len(command) == 0 {
+			return nil, fmt.Errorf("%s is required by the %s credential store", credentialHelperFlag, helperCredentialStore)
+		}
+		return &helperStore{command: command}, nil
+	default:
+		return nil, fmt.Errorf("unsupported credential store %q, expected %s or %s", kind, fileCredentialStore, helperCredentialStore)
+	}
+}
+
+// credentialName returns the name the secrets of the config are stored
+// under. It defaults to the name of the selected context.
+func credentialName() string {
+	if name := viper.GetString(credentialNameFlag); name != "" {
+		return name
+	}
+	if name := activeContext(); name != "" {
+		return name
+	}
+	return defaultCredentialName
+}
+
+// loadCredentials sets up the configured credential store and overrides
+// the secrets of the config with the stored ones.
+func loadCredentials() error {
+	store, err := newCredentialStore()
+	if err != nil {
+		return err
+	}
+	activeCredentialStore = store
+	if store == nil {
+		return nil
+	}
+	secrets, err := store.get(credentialName())
+	if err != nil {
+		return fmt.Errorf("could not read credentials: %v", err)
+	}
+	for key, name := range credentialKeys {
+		if value := secrets[name]; value != "" {
+			viper.Set(key, value)
+		}
+	}
+	return nil
+}
+
+// saveCredentials moves the secrets out of the values into the credential
+// store. The returned values clear the secrets left in the configuration
+// file.
+func saveCredentials(values map[string]interface{}) (map[string]interface{}, error) {
+	if activeCredentialStore == nil {
+		return values, nil
+	}
+	rest := make(map[string]interface{}, len(values))
+	changed := map[string]string{}
+	for key, value := range values {
+		name, ok := credentialKeys[key]
+		if !ok {
+			rest[key] = value
+			continue
+		}
+		changed[name] = fmt.Sprint(value)
+		if viper.InConfig(configKey(key)) {
+			rest[key] = ""
+		}
+	}
+	if len(changed) == 0 {
+		return values, nil
+	}
+
+	if err := storeSecrets(credentialName(), changed); err != nil {
+		return nil, err
+	}
+	return rest, nil
+}
+
+// storeSecrets merges the changed secrets into the ones stored under the
+// name. Empty values remove secrets.
+func storeSecrets(name string, changed map[string]string) error {
+	secrets, err := activeCredentialStore.get(name)
+	if err != nil {
+		return fmt.Errorf("could not read credentials: %v", err)
+	}
+	if secrets == nil {
+		secrets = map[string]string{}
+	}
+	for key, value := range changed {
+		if value == "" {
+			delete(secrets, key)
+		} else {
+			secrets[key] = value
+		}
+	}
+	if len(secrets) == 0 {
+		err = activeCredentialStore.erase(name)
+	} else {
+		err = activeCredentialStore.store(name, secrets)
+	}
+	if err != nil {
+		return fmt.Errorf("could not store credentials: %v", err)
+	}
+	return nil
+}
+
+// encryptedFileStore keeps the secrets in a file encrypted with AES-GCM
+// and a key derived from a passphrase with scrypt.
+type encryptedFileStore struct {
+	path           string
+	readPassphrase func() (string, error)
+	passphrase     string
+}
+
+// encryptedFile is the format of the file of an encryptedFileStore.
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func (s *encryptedFileStore) get(name string) (map[string]string, error) {
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return all[name], nil
+}
+
+func (s *encryptedFileStore) store(name string, secrets map[string]string) error {
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if all == nil {
+		all = map[string]map[string]string{}
+	}
+	all[name] = secrets
+	return s.write(all)
+}
+
+func (s *encryptedFileStore) erase(name string) error {
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[name]; !ok {
+		return nil
+	}
+	delete(all, name)
+	return s.write(all)
+}
+
+// read decrypts the file. A missing file holds no secrets and does not
+// require the passphrase.
+func (s *encryptedFileStore) read() (map[string]map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file := encryptedFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", s.path, err)
+	}
+	if file.Version != credentialFileVersion {
+		return nil, fmt.Errorf("unsupported version %d of %s", file.Version, s.path)
+	}
+	aead, err := s.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt %s, wrong passphrase?", s.path)
+	}
+	all := map[string]map[string]string{}
+	if err := json.Unmarshal(plaintext, &all); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", s.path, err)
+	}
+	return all, nil
+}
+
+// write encrypts the secrets with a new salt and nonce.
+func (s *encryptedFileStore) write(all map[string]map[string]string) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	file := encryptedFile{Version: credentialFileVersion, Salt: make([]byte, 16)}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+	aead, err := s.cipher(file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Data = aead.Seal(nil, file.Nonce, plaintext, nil)
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+func (s *encryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if s.passphrase == "" {
+		passphrase, err := s.readPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, fmt.Errorf("empty passphrase for %s", s.path)
+		}
+		s.passphrase = passphrase
+	}
+	key, err := scrypt.Key([]byte(s.passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readCredentialPassphrase takes the passphrase of the credential file from
+// the environment or asks the user.
+func readCredentialPassphrase() (string, error) {
+	if passphrase := os.Getenv(credentialPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("could not read the credentials passphrase: stdin is not a terminal, set %s", credentialPassphraseEnv)
+	}
+	fmt.Fprintln(os.Stderr, "Credentials passphrase:")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", fmt.Errorf("could not read the credentials passphrase: %v", err)
+	}
+	return string(passphrase), nil
+}
+
+// helperStore runs an external credential helper, similar to the
+// credential helpers of git and docker. The helper is run with the action
+// as its last argument:
+//
+//	get    reads the name on stdin and writes the secrets as a JSON
+//	       object, or an empty object if there are none
+//	store  reads {"name": <name>, "secrets": {...}} on stdin
+//	erase  reads the name on stdin
+type helperStore struct {
+	command []string
+}
+
+// helperRequest is written to the helper by store.
+type helperRequest struct {
+	Name    string            `json:"name"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+func (s *helperStore) get(name string) (map[string]string, error) {
+	out, err := s.run("get", []byte(name))
+	if err != nil {
+		return nil, err
+	}
+	secrets := map[string]string{}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(out, &secrets); err != nil {
+		return nil, fmt.Errorf("could not parse the output of %s: %v", s.command[0], err)
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	return secrets, nil
+}
+
+func (s *helperStore) store(name string, secrets map[string]string) error {
+	input, err := json.Marshal(helperRequest{Name: name, Secrets: secrets})
+	if err != nil {
+		return err
+	}
+	_, err = s.run("store", input)
+	return err
+}
+
+func (s *helperStore) erase(name string) error {
+	_, err := s.run("erase", []byte(name))
+	return err
+}
+
+func (s *helperStore) run(action string, input []byte) ([]byte, error) {
+	args := append(append([]string{}, s.command[1:]...), action)
+	cmd := exec.Command(s.command[0], args...)
+	cmd.Stdin = bytes.NewReader(input)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper %s %s failed: %v: %s", s.command[0], action, err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	passphrase := func() (string, error) { return "correct horse", nil }
+	s := &encryptedFileStore{path: path, readPassphrase: passphrase}
+
+	secrets, err := s.get("dev")
+	require.NoError(t, err)
+	require.Nil(t, secrets)
+
+	require.NoError(t, s.store("dev", map[string]string{"token": "top-secret"}))
+	require.NoError(t, s.store("prod", map[string]string{"token": "other"}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "top-secret")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	s = &encryptedFileStore{path: path, readPassphrase: passphrase}
+	secrets, err = s.get("dev")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"token": "top-secret"}, secrets)
+
+	require.NoError(t, s.erase("dev"))
+	secrets, err = s.get("dev")
+	require.NoError(t, err)
+	require.Nil(t, secrets)
+
+	wrong := &encryptedFileStore{path: path, readPassphrase: func() (string, error) { return "wrong", nil }}
+	_, err = wrong.get("prod")
+	require.ErrorContains(t, err, "wrong passphrase")
+}
+
+// helperScript records the input of every action and answers get with the
+// contents of secrets.json.
+const helperScript = `#!/bin/sh
+dir=$(dirname "$0")
+cat > "$dir/$1.in"
+if [ "$1" = get ]; then
+  cat "$dir/secrets.json" 2>/dev/null
+fi
+exit 0
+`
+
+func TestHelperCredentialStore(t *testing.T) {
+	t.Cleanup(resetConfig)
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "helper")
+	require.NoError(t, os.WriteFile(helper, []byte(helperScript), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets.json"), []byte(`{"token": "token-1", "session_id": "session-1"}`), 0o600))
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`controllers:
+  sdwan:
+    credentials:
+      store: helper
+      helper: `+helper+`
+      name: lab
+`), 0o600))
+
+	require.NoError(t, initConfig(path))
+	require.Equal(t, "token-1", viper.GetString(tokenFlag))
+	require.Equal(t, "session-1", viper.GetString(sessionIDFlag))
+	input, err := os.ReadFile(filepath.Join(dir, "get.in"))
+	require.NoError(t, err)
+	require.Equal(t, "lab", string(input))
+
+	require.NoError(t, saveConfig(sessionValues("token-2", "session-2", time.Now())))
+	input, err = os.ReadFile(filepath.Join(dir, "store.in"))
+	require.NoError(t, err)
+	request := helperRequest{}
+	require.NoError(t, json.Unmarshal(input, &request))
+	require.Equal(t, helperRequest{
+		Name:    "lab",
+		Secrets: map[string]string{"token": "token-2", "session_id": "session-2"},
+	}, request)
+
+	file, err := readConfigFile()
+	require.NoError(t, err)
+	require.False(t, file.IsSet(tokenFlag))
+	require.False(t, file.IsSet(sessionIDFlag))
+	require.True(t, file.IsSet(sessionExpiryFlag))
+}
+
+func TestSaveConfigMovesSecrets(t *testing.T) {
+	t.Cleanup(resetConfig)
+	t.Setenv(credentialPassphraseEnv, "correct horse")
+	dir := t.TempDir()
+	store := filepath.Join(dir, "credentials")
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`# Controller settings.
+controllers:
+  sdwan:
+    # Written by generate-token.
+    token: plaintext
+    credentials:
+      store: file
+      file: `+store+`
+globals:
+  grpc_url: localhost:80 # the proxy
+`), 0o600))
+
+	require.NoError(t, initConfig(path))
+	require.Equal(t, "plaintext", viper.GetString(tokenFlag))
+	require.NoError(t, saveConfig(map[string]interface{}{tokenFlag: "encrypted"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `# Controller settings.
+controllers:
+  sdwan:
+    # Written by generate-token.
+    token: ""
+    credentials:
+      store: file
+      file: `+store+`
+globals:
+  grpc_url: localhost:80 # the proxy
+`, string(data))
+
+	resetConfig()
+	require.NoError(t, initConfig(path))
+	require.Equal(t, "encrypted", viper.GetString(tokenFlag))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	bearerTokenFlag = globalsFlag + ".bearer_token"
+	usernameFlag    = controllersFlag + ".username"
+
+	authorizationHeader = "authorization"
+	xsrfTokenHeader     = "x-xsrf-token"
+	cookieHeader        = "cookie"
+	sessionCookie       = "JSESSIONID"
+)
+
+// vManageLogin creates a new vManage session, replaced in tests.
+var vManageLogin = loginToVManage
+
+// controllerCredentials attaches the credentials stored in the config to
+// every call: a bearer token if one is configured, otherwise the vManage
+// token and session cookie written by generate-token.
+type controllerCredentials struct {
+	mu          sync.RWMutex
+	bearerToken string
+	token       string
+	sessionID   string
+	// login creates a new vManage session. It is nil when the session
+	// cannot be renewed without the user.
+	login func(ctx context.Context) (string, string, error)
+}
+
+// newControllerCredentials returns the credentials configured in the
+// config or nil if there are none.
+func newControllerCredentials() *controllerCredentials {
+	c := &controllerCredentials{
+		bearerToken: viper.GetString(bearerTokenFlag),
+		token:       viper.GetString(tokenFlag),
+		sessionID:   viper.GetString(sessionIDFlag),
+	}
+	if c.bearerToken == "" && c.token == "" && c.sessionID == "" {
+		return nil
+	}
+	username, password := viper.GetString(usernameFlag), os.Getenv(environmentVariableFlag)
+	if c.bearerToken == "" && username != "" && password != "" {
+		c.login = func(ctx context.Context) (string, string, error) {
+			return vManageLogin(ctx, username, password)
+		}
+	}
+	return c
+}
+
+func (c *controllerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.bearerToken != "" {
+		return map[string]string{authorizationHeader: "Bearer " + c.bearerToken}, nil
+	}
+	md := map[string]string{}
+	if c.token != "" {
+		md[xsrfTokenHeader] = c.token
+	}
+	if c.sessionID != "" {
+		md[cookieHeader] = sessionCookie + "=" + c.sessionID
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity allows sending the credentials over plaintext,
+// as the proxy is often reached on localhost. Enable TLS in the config to
+// protect them.
+func (c *controllerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// relogin replaces the vManage session and stores the new one in the
+// config.
+func (c *controllerCredentials) relogin(ctx context.Context) error {
+	token, sessionID, err := c.login(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token, c.sessionID = token, sessionID
+	c.mu.Unlock()
+	return saveConfig(sessionValues(token, sessionID, time.Now()))
+}
+
+// reloginInterceptor logs in again and retries the call once when the
+// controller rejects the vManage session as expired.
+func reloginInterceptor(c *controllerCredentials) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		if c.login == nil {
+			return fmt.Errorf("%v, run generate-token to log in again", err)
+		}
+		logger.Infof("session expired, logging in again")
+		if err := c.relogin(ctx); err != nil {
+			return fmt.Errorf("could not log in again: %v", err)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
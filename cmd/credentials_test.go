@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// sessionServer accepts calls only with the current vManage session.
+type sessionServer struct {
+	awi.UnimplementedConnectionControllerServer
+	token, sessionID string
+}
+
+func (s *sessionServer) ListConnections(ctx context.Context, _ *awi.ListConnectionsRequest) (*awi.ListConnectionsResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(xsrfTokenHeader)) == 0 || md.Get(xsrfTokenHeader)[0] != s.token ||
+		len(md.Get(cookieHeader)) == 0 || md.Get(cookieHeader)[0] != sessionCookie+"="+s.sessionID {
+		return nil, status.Error(codes.Unauthenticated, "session expired")
+	}
+	return &awi.ListConnectionsResponse{}, nil
+}
+
+func TestControllerCredentialsRelogin(t *testing.T) {
+	t.Cleanup(func() {
+		resetConfig()
+		vManageLogin = loginToVManage
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	awi.RegisterConnectionControllerServer(s, &sessionServer{token: "token-2", sessionID: "session-2"})
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	defer s.Stop()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`globals:
+  grpc_url: `+listener.Addr().String()+`
+  use_proxy: false
+controllers:
+  sdwan:
+    username: admin
+    token: token-1
+    session_id: session-1
+`), 0o600))
+	require.NoError(t, initConfig(path))
+	t.Setenv(environmentVariableFlag, "secret")
+	logins := 0
+	vManageLogin = func(_ context.Context, username, password string) (string, string, error) {
+		require.Equal(t, "admin", username)
+		require.Equal(t, "secret", password)
+		logins++
+		return "token-2", "session-2", nil
+	}
+
+	conn, err := getGRPCClient()
+	require.NoError(t, err)
+	defer connClose(conn)
+	c := awi.NewConnectionControllerClient(conn)
+	for i := 0; i < 2; i++ {
+		_, err = c.ListConnections(context.Background(), &awi.ListConnectionsRequest{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, logins)
+
+	file, err := readConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "token-2", file.GetString(tokenFlag))
+	require.Equal(t, "session-2", file.GetString(sessionIDFlag))
+}
+
+func TestControllerCredentialsBearer(t *testing.T) {
+	t.Cleanup(resetConfig)
+	viper.Set(bearerTokenFlag, "abc")
+	viper.Set(tokenFlag, "ignored")
+	c := newControllerCredentials()
+	md, err := c.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{authorizationHeader: "Bearer abc"}, md)
+	require.Nil(t, c.login)
+}
@@ -17,14 +17,102 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const (
+	ignoreNotFoundFlag = "ignore-not-found"
+)
 
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete resources",
+	Long: `Delete resources either by ID using one of the subcommands or by name
+using the manifests the resources were created from.
+
+Resources read from manifests are looked up by name and deleted in the
+reverse order of the documents, so that objects depending on others
+defined earlier in the same files are removed first.`,
+	Args:        cobra.NoArgs,
+	RunE:        deleteFromManifests,
+	Annotations: map[string]string{helpWithoutFlagAnnotation: filenameFlag},
+}
+
+func deleteFromManifests(cmd *cobra.Command, _ []string) error {
+	filename := cmd.Flag(filenameFlag).Value.String()
+	if filename == "" {
+		return cmd.Help()
+	}
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return err
+	}
+	ignoreNotFound, err := cmd.Flags().GetBool(ignoreNotFoundFlag)
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(filename, recursive)
+	if err != nil {
+		return err
+	}
+	for i, j := 0, len(documents)-1; i < j; i, j = i+1, j-1 {
+		documents[i], documents[j] = documents[j], documents[i]
+	}
+
+	conn, err := clients.connection()
+	if err != nil {
+		return err
+	}
+
+	return processDocuments(documents, func(doc document) error {
+		resource, err := newManifestResource(doc.Viper)
+		if err != nil {
+			return err
+		}
+		deleted, err := deleteManifest(conn, resource)
+		if err != nil {
+			return fmt.Errorf("could not delete %s %q: %v", resource.kind(), resource.name(), err)
+		}
+		if !deleted {
+			if !ignoreNotFound {
+				return fmt.Errorf("%s %q not found", resource.kind(), resource.name())
+			}
+			fmt.Printf("%s/%s not found\n", resource.kind(), resource.name())
+			return nil
+		}
+		fmt.Printf("%s/%s deleted\n", resource.kind(), resource.name())
+		return nil
+	})
+}
+
+// deleteManifest deletes the live object matching the manifest name. It
+// returns false if such object does not exist.
+func deleteManifest(conn *grpc.ClientConn, m manifestResource) (bool, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	id, _, err := m.find(ctx, conn)
+	if err != nil {
+		return false, fmt.Errorf("could not look up %s: %v", m.kind(), err)
+	}
+	if id == "" {
+		return false, nil
+	}
+	logger.Infof("sending delete %s %s request", m.kind(), id)
+	if err := m.delete(ctx, conn, id); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.Flags().StringP(filenameFlag, "f", "", "Manifest file, directory of manifests or - for standard input")
+	deleteCmd.Flags().BoolP(recursiveFlag, "R", false, "Process the directory used in -f recursively")
+	deleteCmd.Flags().Bool(ignoreNotFoundFlag, false, "Do not fail when a resource from the manifests does not exist")
 }
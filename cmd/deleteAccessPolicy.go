@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -33,21 +31,16 @@ var deleteAccessPolicyCmd = &cobra.Command{
 	RunE:  deleteAccessPolicy,
 }
 
-func deleteAccessPolicy(cmd *cobra.Command, AccessPolicies []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+func deleteAccessPolicy(_ *cobra.Command, AccessPolicies []string) error {
+	c, err := clients.securityPolicy()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 	for _, policy := range AccessPolicies {
 		deleteRequest := &awi.AccessPolicyDeleteRequest{
 			Name: policy,
 		}
-		c := awi.NewSecurityPolicyServiceClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := requestContext()
 		response, err := c.DeleteAccessPolicy(ctx, deleteRequest)
 		if err != nil {
 			cancel()
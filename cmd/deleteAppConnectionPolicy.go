@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -34,22 +32,17 @@ var deleteAppConnectionPolicyCmd = &cobra.Command{
 	RunE:  deleteAppConnectionPolicy,
 }
 
-func deleteAppConnectionPolicy(cmd *cobra.Command, appConnections []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+func deleteAppConnectionPolicy(_ *cobra.Command, appConnections []string) error {
+	c, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 
 	for _, appConnectionID := range appConnections {
 		disconnectRequest := &awi.DeleteAppConnectionPolicyRequest{
 			Id: appConnectionID,
 		}
-		c := awi.NewAppConnectionControllerClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := requestContext()
 		defer cancel()
 		response, err := c.DeleteAppConnectionPolicy(ctx, disconnectRequest)
 		if err != nil {
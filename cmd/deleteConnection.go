@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -33,21 +31,16 @@ var deleteConnectionCmd = &cobra.Command{
 	RunE:  deleteConnection,
 }
 
-func deleteConnection(cmd *cobra.Command, connections []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+func deleteConnection(_ *cobra.Command, connections []string) error {
+	c, err := clients.connectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 	for _, connectionID := range connections {
 		disconnectRequest := &awi.DisconnectRequest{
 			ConnectionId: connectionID,
 		}
-		c := awi.NewConnectionControllerClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := requestContext()
 		defer cancel()
 		response, err := c.Disconnect(ctx, disconnectRequest)
 		if err != nil {
@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -33,21 +31,16 @@ var deleteNetworkSLACmd = &cobra.Command{
 	RunE:  deleteNetworkSLA,
 }
 
-func deleteNetworkSLA(cmd *cobra.Command, networkSLAs []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+func deleteNetworkSLA(_ *cobra.Command, networkSLAs []string) error {
+	c, err := clients.networkSLA()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 	for _, sla := range networkSLAs {
 		deleteRequest := &awi.NetworkSLADeleteRequest{
 			Name: sla,
 		}
-		c := awi.NewNetworkSLAServiceClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := requestContext()
 		response, err := c.DeleteNetworkSLA(ctx, deleteRequest)
 		if err != nil {
 			cancel()
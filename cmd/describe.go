@@ -0,0 +1,489 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+	"github.com/spf13/cobra"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+const noneField = "<none>"
+
+// describeCmd represents the describe command
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show details of a resource and the resources it refers to",
+}
+
+// describeConnectionCmd represents the describe Connection command
+var describeConnectionCmd = &cobra.Command{
+	Use:   "connection <id|name>",
+	Short: "Describe a Connection",
+	Long: `Describe a Connection together with the network domains it connects,
+their subnets and instances, the access policies and network SLAs matched
+by its selectors and the app connections created on top of it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: describeConnection,
+}
+
+// describeAppConnectionCmd represents the describe AppConnection command
+var describeAppConnectionCmd = &cobra.Command{
+	Use:   "app-connection <id>",
+	Short: "Describe an Application Connection",
+	Long: `Describe an Application Connection together with its network domain
+connection, the access policies and network SLAs matched by its selectors
+and the instances, subnets, pods and services matched on both sides.`,
+	Args: cobra.ExactArgs(1),
+	RunE: describeAppConnection,
+}
+
+// describer looks up the resources referred to by a described resource.
+// Every call to the controller gets its own request timeout, since a
+// report takes many of them.
+type describer struct {
+	resolver       *selectorResolver
+	appConnections awi.AppConnectionControllerClient
+	policies       awi.SecurityPolicyServiceClient
+	slas           awi.NetworkSLAServiceClient
+	now            time.Time
+}
+
+func newDescriber(now time.Time) (*describer, error) {
+	d := &describer{resolver: &selectorResolver{}, now: now}
+	var err error
+	if d.resolver.cloud, err = clients.cloud(); err != nil {
+		return nil, err
+	}
+	if d.resolver.infra, err = clients.cloudProvider(); err != nil {
+		return nil, err
+	}
+	if d.resolver.connections, err = clients.connectionController(); err != nil {
+		return nil, err
+	}
+	if d.appConnections, err = clients.appConnectionController(); err != nil {
+		return nil, err
+	}
+	if d.policies, err = clients.securityPolicy(); err != nil {
+		return nil, err
+	}
+	if d.slas, err = clients.networkSLA(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func describeConnection(_ *cobra.Command, args []string) error {
+	d, err := newDescriber(time.Now())
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("sending list Connections request")
+	ctx, cancel := requestContext()
+	connections, err := d.resolver.connections.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not get connection: %v", err)
+	}
+	connection := findConnection(connections.GetConnections(), args[0])
+	if connection == nil {
+		return fmt.Errorf("connection %q not found", args[0])
+	}
+	return d.connection(os.Stdout, connection)
+}
+
+func describeAppConnection(_ *cobra.Command, args []string) error {
+	d, err := newDescriber(time.Now())
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("sending get AppConnection request")
+	ctx, cancel := requestContext()
+	response, err := d.appConnections.GetAppConnection(ctx, &awi.GetAppConnectionRequest{ConnectionId: args[0]})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not get app connection: %v", err)
+	}
+	return d.appConnection(os.Stdout, response.GetAppConnection())
+}
+
+// connection prints the connection and the resources it refers to.
+func (d *describer) connection(w io.Writer, connection *awi.ConnectionInformation) error {
+	config := connection.GetConfig()
+	printFields(w, "", [][2]string{
+		{"Name", connection.GetMetadata().GetName()},
+		{"ID", connection.GetId()},
+		{"Status", connection.GetStatus().String()},
+		{"Labels", describeLabels(connection.GetMetadata().GetLabels())},
+		{"Created", describeTimestamp(connection.GetCreationTimestamp(), d.now)},
+		{"Modified", describeTimestamp(connection.GetModificationTimestamp(), d.now)},
+	})
+	sides := []struct {
+		name   string
+		domain *awi.NetworkDomainConnectionConfig_NetworkDomain
+		object *awi.NetworkDomainObject
+	}{
+		{"Source", config.GetSource().GetNetworkDomain(), connection.GetSource()},
+		{"Destination", config.GetDestination().GetNetworkDomain(), connection.GetDestination()},
+	}
+	for _, side := range sides {
+		fmt.Fprintf(w, "%s:\n", side.name)
+		if err := d.networkDomain(w, side.domain, side.object); err != nil {
+			return err
+		}
+	}
+	if err := d.accessPolicies(w, connectionPolicySelector(config.GetAccessPolicy().GetSelector())); err != nil {
+		return err
+	}
+	if err := d.networkSLAs(w, connectionPolicySelector(config.GetNetworkPolicy().GetSelector())); err != nil {
+		return err
+	}
+	return d.connectionAppConnections(w, connection.GetMetadata().GetName())
+}
+
+// networkDomain prints one side of a connection: the selector, the network
+// domain the controller resolved it to and, for VPCs, its subnets and
+// instances.
+func (d *describer) networkDomain(w io.Writer, domain *awi.NetworkDomainConnectionConfig_NetworkDomain, object *awi.NetworkDomainObject) error {
+	selector := connectionDomainSelector(domain).String()
+	if site := domain.GetSelector().GetMatchSite(); site != nil {
+		selector = "matchSite " + site.GetId()
+	}
+	printFields(w, "  ", [][2]string{
+		{"Selector", valueOrNone(selector)},
+		{"Network Domain", describeNetworkDomainObject(object)},
+	})
+	if object.GetId() == "" || object.GetType() != "VPC" {
+		return nil
+	}
+	ctx, cancel := requestContext()
+	subnets, err := d.resolver.subnets(ctx, object.GetId(), nil, nil)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  Subnets:\n")
+	printSubnets(w, "    ", subnets)
+	ctx, cancel = requestContext()
+	instances, err := d.resolver.instances(ctx, object.GetId(), nil, "")
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  Instances:\n")
+	printInstances(w, "    ", instances)
+	return nil
+}
+
+// connectionAppConnections prints the app connections created on top of
+// the connection.
+func (d *describer) connectionAppConnections(w io.Writer, name string) error {
+	ctx, cancel := requestContext()
+	connections, err := d.appConnections.ListConnectedApps(ctx, &awi.ListAppConnectionsRequest{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not list app connections: %v", err)
+	}
+	fmt.Fprintf(w, "App Connections:\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	found := false
+	for _, connection := range connections.GetAppConnections() {
+		if connection.GetNetworkDomainConnectionName() != name {
+			continue
+		}
+		if !found {
+			fmt.Fprintf(tw, "  ID\tNAME\tSTATUS\n")
+			found = true
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", connection.GetId(),
+			connection.GetAppConnectionConfig().GetMetadata().GetName(), connection.GetStatus().String())
+	}
+	if !found {
+		fmt.Fprintf(tw, "  %s\n", noneField)
+	}
+	return tw.Flush()
+}
+
+// appConnection prints the app connection and the resources it refers to.
+func (d *describer) appConnection(w io.Writer, connection *awi.AppConnectionInformation) error {
+	config := connection.GetAppConnectionConfig()
+	printFields(w, "", [][2]string{
+		{"Name", config.GetMetadata().GetName()},
+		{"ID", connection.GetId()},
+		{"Status", connection.GetStatus().String()},
+		{"Controller", valueOrNone(config.GetController())},
+		{"Labels", describeLabels(config.GetMetadata().GetLabel())},
+		{"Created", describeTimestamp(config.GetMetadata().GetCreationTimestamp(), d.now)},
+		{"Modified", describeTimestamp(config.GetMetadata().GetModificationTimestamp(), d.now)},
+	})
+	name := connection.GetNetworkDomainConnectionName()
+	if name == "" {
+		name = config.GetNetworkDomainConnection().GetSelector().GetMatchName()
+	}
+	if err := d.networkDomainConnection(w, name); err != nil {
+		return err
+	}
+	if err := d.accessPolicies(w, appPolicySelector(config.GetAccessPolicy().GetSelector())); err != nil {
+		return err
+	}
+	slaSelector := domainSelector{name: config.GetNetworkPolicy().GetSelector().GetMatchName()}
+	if err := d.networkSLAs(w, slaSelector); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Source:\n")
+	printMatchedResources(w, "  ", connection.GetSourceMatched())
+	fmt.Fprintf(w, "Destination:\n")
+	printMatchedResources(w, "  ", connection.GetDestinationMatched())
+	return nil
+}
+
+// networkDomainConnection prints the connection an app connection is
+// created for.
+func (d *describer) networkDomainConnection(w io.Writer, name string) error {
+	fmt.Fprintf(w, "Network Domain Connection:\n")
+	if name == "" {
+		fmt.Fprintf(w, "  %s\n", noneField)
+		return nil
+	}
+	ctx, cancel := requestContext()
+	connections, err := d.resolver.connections.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not list connections: %v", err)
+	}
+	connection := findConnection(connections.GetConnections(), name)
+	if connection == nil {
+		fmt.Fprintf(w, "  no connection named %s\n", name)
+		return nil
+	}
+	printFields(w, "  ", [][2]string{
+		{"Name", name},
+		{"ID", connection.GetId()},
+		{"Status", connection.GetStatus().String()},
+		{"Source", describeNetworkDomainObject(connection.GetSource())},
+		{"Destination", describeNetworkDomainObject(connection.GetDestination())},
+	})
+	return nil
+}
+
+// accessPolicies prints the selector of access policies and the policies
+// matching it.
+func (d *describer) accessPolicies(w io.Writer, s domainSelector) error {
+	fmt.Fprintf(w, "Access Policies:\n")
+	if s.empty() {
+		fmt.Fprintf(w, "  %s\n", noneField)
+		return nil
+	}
+	ctx, cancel := requestContext()
+	policies, err := d.policies.ListAccessPolicies(ctx, &awi.AccessPolicyListRequest{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not list access policies: %v", err)
+	}
+	fmt.Fprintf(w, "  Selector:  %s\n", s)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	found := false
+	for _, policy := range policies.GetAccessPolicies() {
+		if !s.matchesPolicy(policy.GetMetadata().GetName(), policy.GetMetadata().GetLabels()) {
+			continue
+		}
+		if !found {
+			fmt.Fprintf(tw, "  NAME\tACCESS\tPRIORITY\tPROTOCOLS\n")
+			found = true
+		}
+		protocols := make([]string, 0, len(policy.GetAccessProtocols()))
+		for _, protocol := range policy.GetAccessProtocols() {
+			if protocol.GetPort() == "" {
+				protocols = append(protocols, protocol.GetProtocol())
+			} else {
+				protocols = append(protocols, protocol.GetProtocol()+"/"+protocol.GetPort())
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%d\t%s\n", policy.GetMetadata().GetName(), valueOrNone(policy.GetAccessType()),
+			policy.GetPriority(), valueOrNone(strings.Join(protocols, ",")))
+	}
+	if !found {
+		fmt.Fprintf(tw, "  no access policies match\n")
+	}
+	return tw.Flush()
+}
+
+// networkSLAs prints the selector of network SLAs and the SLAs matching it.
+func (d *describer) networkSLAs(w io.Writer, s domainSelector) error {
+	fmt.Fprintf(w, "Network SLAs:\n")
+	if s.empty() {
+		fmt.Fprintf(w, "  %s\n", noneField)
+		return nil
+	}
+	ctx, cancel := requestContext()
+	slas, err := d.slas.ListNetworkSLAs(ctx, &awi.NetworkSLAListReqest{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("could not list network SLAs: %v", err)
+	}
+	fmt.Fprintf(w, "  Selector:  %s\n", s)
+	var matched []*awi.NetworkSLA
+	for _, sla := range slas.GetNetworkSLAs() {
+		if s.matchesPolicy(sla.GetMetadata().GetName(), nil) {
+			matched = append(matched, sla)
+		}
+	}
+	if len(matched) == 0 {
+		fmt.Fprintf(w, "  no network SLAs match\n")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "  NAME\tBANDWIDTH[Mbps]\tLATENCY[Ms]\tJITTER[Ms]\tLOSS[%%]\tPRIORITY\n")
+	for _, sla := range convertNetworksSLAs(matched) {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n", sla.Name, sla.Bandwidth, sla.Latency, sla.Jitter, sla.Loss,
+			valueOrNone(sla.Priority))
+	}
+	return tw.Flush()
+}
+
+// matchesPolicy reports whether the selector matches the policy or SLA,
+// which are identified by their name.
+func (s domainSelector) matchesPolicy(name string, labels map[string]string) bool {
+	if s.empty() {
+		return false
+	}
+	return (s.id == "" || s.id == name) && (s.name == "" || s.name == name) && matchLabels(s.labels, labels)
+}
+
+func connectionPolicySelector(selector *awi.NetworkDomainConnectionConfig_Selector) domainSelector {
+	return domainSelector{
+		id:     selector.GetMatchId().GetId(),
+		name:   selector.GetMatchName().GetName(),
+		labels: selector.GetMatchLabels(),
+	}
+}
+
+func appPolicySelector(selector *awi.AccessPolicySelector_Selector) domainSelector {
+	return domainSelector{
+		id:     selector.GetMatchId().GetId(),
+		name:   selector.GetMatchName().GetName(),
+		labels: selector.GetMatchLabels(),
+	}
+}
+
+// printMatchedResources prints the resources the controller matched on one
+// side of an app connection.
+func printMatchedResources(w io.Writer, indent string, matched *awi.MatchedResources) {
+	instances := make([]*infrapb.Instance, 0, len(matched.GetMatchedInstances()))
+	for _, instance := range matched.GetMatchedInstances() {
+		instances = append(instances, &infrapb.Instance{
+			Id:        instance.GetID(),
+			Name:      instance.GetName(),
+			PrivateIP: instance.GetPrivateIP(),
+			SubnetID:  instance.GetSubnetID(),
+			VpcId:     instance.GetVPCID(),
+		})
+	}
+	fmt.Fprintf(w, "%sMatched Instances:\n", indent)
+	printInstances(w, indent+"  ", instances)
+
+	subnets := make([]*infrapb.Subnet, 0, len(matched.GetMatchedSubnets()))
+	for _, subnet := range matched.GetMatchedSubnets() {
+		subnets = append(subnets, &infrapb.Subnet{
+			SubnetId:  subnet.GetSubnetId(),
+			Name:      subnet.GetName(),
+			CidrBlock: subnet.GetCidrBlock(),
+			VpcId:     subnet.GetVpcId(),
+			Zone:      subnet.GetZone(),
+		})
+	}
+	fmt.Fprintf(w, "%sMatched Subnets:\n", indent)
+	printSubnets(w, indent+"  ", subnets)
+
+	if pods := matched.GetMatchedPods(); len(pods) > 0 {
+		fmt.Fprintf(w, "%sMatched Pods:\n", indent)
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "%s  POD\tNAMESPACE\tCLUSTER\tIP\n", indent)
+		for _, pod := range pods {
+			fmt.Fprintf(tw, "%s  %s\t%s\t%s\t%s\n", indent, pod.GetName(), pod.GetNamespace(), pod.GetCluster(), pod.GetIp())
+		}
+		_ = tw.Flush()
+	}
+	if services := matched.GetMatchedServices(); len(services) > 0 {
+		fmt.Fprintf(w, "%sMatched Services:\n", indent)
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "%s  SERVICE\tNAMESPACE\tCLUSTER\n", indent)
+		for _, service := range services {
+			fmt.Fprintf(tw, "%s  %s\t%s\t%s\n", indent, service.GetName(), service.GetNamespace(), service.GetCluster())
+		}
+		_ = tw.Flush()
+	}
+}
+
+// printFields prints the fields as aligned "Key:  value" lines.
+func printFields(w io.Writer, indent string, fields [][2]string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s%s:\t%s\n", indent, field[0], field[1])
+	}
+	_ = tw.Flush()
+}
+
+func describeNetworkDomainObject(object *awi.NetworkDomainObject) string {
+	if object.GetId() == "" {
+		return noneField
+	}
+	return fmt.Sprintf("%s %s %s (%s)", object.GetType(), object.GetProvider(), object.GetId(), object.GetName())
+}
+
+// describeTimestamp prints the timestamp followed by how long ago it was.
+func describeTimestamp(value string, now time.Time) string {
+	if value == "" {
+		return noneField
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return value
+	}
+	return fmt.Sprintf("%s (%s)", value, prettyprint.FormatTimestamp(value, prettyprint.TimestampsRelative, now))
+}
+
+func describeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return noneField
+	}
+	return formatLabels(labels)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return noneField
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.AddCommand(describeConnectionCmd)
+	describeCmd.AddCommand(describeAppConnectionCmd)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/app-net-interface/awi-cli/fake"
+)
+
+const (
+	listenFlag = "listen"
+
+	defaultFakeServerAddress = "localhost:50051"
+)
+
+// devCmd represents the dev command
+var devCmd = &cobra.Command{
+	Use:         "dev",
+	Short:       "Tools for developing and demoing the CLI",
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+}
+
+var fakeServerCmd = &cobra.Command{
+	Use:   "fake-server",
+	Short: "Run a fake AWI controller which keeps its state in memory",
+	Long: fmt.Sprintf(`Run a fake AWI controller which keeps its state in memory.
+
+The fake controller serves the APIs used by the CLI over a fixed inventory
+of AWS and GCP VPCs, subnets, instances and SD-WAN VPNs. Created resources
+reach status SUCCESS immediately and are lost when the server stops.
+
+Point the CLI at it with a config like:
+
+  globals:
+    grpc_url: %s
+    use_proxy: false`, defaultFakeServerAddress),
+	Args: cobra.NoArgs,
+	RunE: runFakeServer,
+}
+
+func runFakeServer(cmd *cobra.Command, _ []string) error {
+	address := cmd.Flag(listenFlag).Value.String()
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %v", address, err)
+	}
+	server := fake.NewServer(fake.NewController(), grpc.UnaryInterceptor(logCallInterceptor))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Printf("Fake controller listening on %s\n", listener.Addr())
+	return server.Serve(listener)
+}
+
+// logCallInterceptor logs the calls served by the fake controller.
+func logCallInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		logger.Infof("%s: %v", info.FullMethod, err)
+	} else {
+		logger.Infof("%s", info.FullMethod)
+	}
+	return resp, err
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(fakeServerCmd)
+	fakeServerCmd.Flags().String(listenFlag, defaultFakeServerAddress, "Address to listen on")
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	diffFormatFlag       = "diff-format"
+	unifiedDiffFormat    = "unified"
+	structuredDiffFormat = "structured"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare manifests with the live resources",
+	Long: `Compare manifests with the live resources of the same name.
+
+Both sides are normalized and fields populated by the controller, like
+creation and modification timestamps and status, are ignored. Lines removed
+from the live resource are prefixed with "-" and lines added by the manifest
+with "+".
+
+The command exits with a non-zero status when any resource differs from its
+manifest or does not exist yet.`,
+	Args: cobra.NoArgs,
+	RunE: diff,
+}
+
+func diff(cmd *cobra.Command, _ []string) error {
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return err
+	}
+	format := cmd.Flag(diffFormatFlag).Value.String()
+	if format != unifiedDiffFormat && format != structuredDiffFormat {
+		return fmt.Errorf("unsupported diff format %q, expected %s or %s", format, unifiedDiffFormat, structuredDiffFormat)
+	}
+	documents, err := readDocuments(cmd.Flag(filenameFlag).Value.String(), recursive)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	conn, err := clients.connection()
+	if err != nil {
+		return err
+	}
+
+	different := 0
+	err = processDocuments(documents, func(doc document) error {
+		resource, err := newManifestResource(doc.Viper)
+		if err != nil {
+			return err
+		}
+		differs, err := diffManifest(conn, resource, format, os.Stdout)
+		if err != nil {
+			return fmt.Errorf("could not compare %s %q: %v", resource.kind(), resource.name(), err)
+		}
+		if differs {
+			different++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if different > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d resources differ from their manifests", different, len(documents))
+	}
+	return nil
+}
+
+// diffManifest prints the differences between the live resource and the
+// manifest and reports whether there are any.
+func diffManifest(conn *grpc.ClientConn, m manifestResource, format string, w io.Writer) (bool, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	live, err := fetchLive(ctx, conn, m)
+	if err != nil {
+		return false, err
+	}
+	desired, err := diffable(m.desired())
+	if err != nil {
+		return false, err
+	}
+	actual := map[string]interface{}{}
+	if live != nil {
+		actual, err = diffable(m.actual(live))
+		if err != nil {
+			return false, err
+		}
+	}
+	title := fmt.Sprintf("%s/%s", m.kind(), m.name())
+	if live == nil {
+		title += " (not found)"
+	}
+	if format == structuredDiffFormat {
+		return structuredDiff(w, title, actual, desired), nil
+	}
+	return unifiedDiff(w, title, actual, desired)
+}
+
+// fetchLive returns the live resource with the name of the manifest or nil
+// if there is none. App connections are read with GetAppConnection, and
+// app connection policies are looked up when no app connection matches.
+func fetchLive(ctx context.Context, conn *grpc.ClientConn, m manifestResource) (proto.Message, error) {
+	id, live, err := m.find(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up %s: %v", m.kind(), err)
+	}
+	if _, ok := m.(*appConnectionManifest); !ok {
+		return live, nil
+	}
+	c := awi.NewAppConnectionControllerClient(conn)
+	if id != "" {
+		response, err := c.GetAppConnection(ctx, &awi.GetAppConnectionRequest{ConnectionId: id})
+		if err != nil {
+			return nil, fmt.Errorf("could not get app connection %s: %v", id, err)
+		}
+		return response.GetAppConnection(), nil
+	}
+	policies, err := c.ListAppConnectionPolicies(ctx, &awi.ListAppConnectionPoliciesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list app connection policies: %v", err)
+	}
+	for _, policy := range policies.GetAppConnectionPolicies() {
+		if policy.GetAppConnection().GetMetadata().GetName() != m.name() {
+			continue
+		}
+		response, err := c.GetAppConnectionPolicy(ctx, &awi.GetAppConnectionPolicyRequest{Id: policy.GetId()})
+		if err != nil {
+			return nil, fmt.Errorf("could not get app connection policy %s: %v", policy.GetId(), err)
+		}
+		return response.GetAppConnectionPolicy(), nil
+	}
+	return nil, nil
+}
+
+// diffable converts the normalized message to generic JSON values so that
+// both sides are rendered the same way.
+func diffable(m proto.Message) (map[string]interface{}, error) {
+	b, err := protojson.Marshal(normalize(m))
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func unifiedDiff(w io.Writer, title string, actual, desired map[string]interface{}) (bool, error) {
+	a, err := json.MarshalIndent(actual, "", "    ")
+	if err != nil {
+		return false, err
+	}
+	b, err := json.MarshalIndent(desired, "", "    ")
+	if err != nil {
+		return false, err
+	}
+	text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a) + "\n"),
+		B:        difflib.SplitLines(string(b) + "\n"),
+		FromFile: "live/" + title,
+		ToFile:   "manifest/" + title,
+		Context:  3,
+	})
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprint(w, text)
+	return text != "", nil
+}
+
+func structuredDiff(w io.Writer, title string, actual, desired map[string]interface{}) bool {
+	a, b := map[string]string{}, map[string]string{}
+	flatten(a, "", actual)
+	flatten(b, "", desired)
+	paths := make([]string, 0, len(a)+len(b))
+	for path := range a {
+		paths = append(paths, path)
+	}
+	for path := range b {
+		if _, ok := a[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var lines []string
+	for _, path := range paths {
+		old, inLive := a[path]
+		value, inManifest := b[path]
+		switch {
+		case !inManifest:
+			lines = append(lines, fmt.Sprintf("- %s: %s", path, old))
+		case !inLive:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", path, value))
+		case old != value:
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", path, old, value))
+		}
+	}
+	if len(lines) == 0 {
+		return false
+	}
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, line := range lines {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	return true
+}
+
+// flatten stores every leaf value of obj under its dotted path.
+func flatten(values map[string]string, path string, obj interface{}) {
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range obj {
+			if path != "" {
+				key = path + "." + key
+			}
+			flatten(values, key, value)
+		}
+	case []interface{}:
+		for i, value := range obj {
+			flatten(values, fmt.Sprintf("%s[%d]", path, i), value)
+		}
+	default:
+		b, _ := json.Marshal(obj)
+		values[path] = string(b)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringP(filenameFlag, "f", "", "Manifest file, directory of manifests or - for standard input")
+	_ = diffCmd.MarkFlagRequired(filenameFlag)
+	diffCmd.Flags().BoolP(recursiveFlag, "R", false, "Process the directory used in -f recursively")
+	diffCmd.Flags().String(diffFormatFlag, unifiedDiffFormat, "Diff format: "+unifiedDiffFormat+" or "+structuredDiffFormat)
+}
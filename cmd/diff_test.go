@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDiffManifest(t *testing.T) {
+	documents, err := decodeDocuments(strings.NewReader(`kind: InterNetworkDomainAppConnection
+spec:
+  appConnection:
+    metadata:
+      name: app-1
+    from:
+      endpoint:
+        selector:
+          matchLabels:
+            env: staging
+`), "test")
+	require.NoError(t, err)
+	resource, err := newManifestResource(documents[0].Viper)
+	require.NoError(t, err)
+
+	config := proto.Clone(resource.desired()).(*awi.AppConnection)
+	config.Metadata.CreationTimestamp = "2024-02-01T10:00:00Z"
+	live := &awi.AppConnectionInformation{Id: "1", AppConnectionConfig: config, Status: awi.Status_SUCCESS}
+	require.True(t, equalManifest(resource, live))
+
+	config.From.Endpoint.Selector.MatchLabels["env"] = "prod"
+	config.Controller = "vmanage"
+	require.False(t, equalManifest(resource, live))
+
+	actual, err := diffable(resource.actual(live))
+	require.NoError(t, err)
+	desired, err := diffable(resource.desired())
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.True(t, structuredDiff(&out, "app-1", actual, desired))
+	require.Equal(t, `app-1:
+  - controller: "vmanage"
+  ~ from.endpoint.selector.matchLabels.env: "prod" -> "staging"
+`, out.String())
+
+	out.Reset()
+	differs, err := unifiedDiff(&out, "app-1", desired, desired)
+	require.NoError(t, err)
+	require.False(t, differs)
+	require.Empty(t, out.String())
+}
+
+func TestNormalizeKeepsNestedStatus(t *testing.T) {
+	live := &awi.GetAppConnectionResponse{AppConnection: &awi.AppConnectionInformation{
+		Id:     "1",
+		Status: awi.Status_SUCCESS,
+		AppConnectionConfig: &awi.AppConnection{Metadata: &awi.AppMetadata{
+			Name:              "app-1",
+			CreationTimestamp: "2024-02-01T10:00:00Z",
+		}},
+	}}
+	normalized := normalize(live).(*awi.GetAppConnectionResponse)
+	require.Equal(t, awi.Status_SUCCESS, normalized.GetAppConnection().GetStatus())
+	require.Equal(t, "2024-02-01T10:00:00Z", normalized.GetAppConnection().GetAppConnectionConfig().GetMetadata().GetCreationTimestamp())
+
+	config := normalize(live.AppConnection.AppConnectionConfig).(*awi.AppConnection)
+	require.Equal(t, "app-1", config.GetMetadata().GetName())
+	require.Empty(t, config.GetMetadata().GetCreationTimestamp())
+
+	information := normalize(live.AppConnection).(*awi.AppConnectionInformation)
+	require.Equal(t, awi.Status_IN_PROGRESS, information.GetStatus())
+}
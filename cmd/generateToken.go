@@ -20,9 +20,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,62 +34,142 @@ import (
 
 const (
 	usernameNameFlag        = "username"
+	passwordStdinFlag       = "password-stdin"
+	passwordFileFlag        = "password-file"
 	environmentVariableFlag = "VMANAGE_PASSWORD"
 )
 
+var (
+	// passwordInput and isTerminal are replaced in tests.
+	passwordInput io.Reader = os.Stdin
+	isTerminal              = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+)
+
 // generateTokenCmd represents the listVpcTag command
 var generateTokenCmd = &cobra.Command{
 	Use:   "generate-token",
 	Short: "Update config with generated tokens",
-	Long:  fmt.Sprintf("Update config with generated tokens. It tries to take password from %v environment variable. If password is not found it will ask user to provide it", environmentVariableFlag),
-	RunE:  generateToken,
+	Long: fmt.Sprintf(`Update config with generated tokens.
+
+The password is read from standard input with --%s, from a file with
+--%s or from the %v environment variable. If none of them is used, the user
+is asked to provide it, which requires a terminal.
+
+The username defaults to %s in the config.`,
+		passwordStdinFlag, passwordFileFlag, environmentVariableFlag, usernameFlag),
+	RunE: generateToken,
 }
 
 func generateToken(cmd *cobra.Command, _ []string) error {
-	password := os.Getenv(environmentVariableFlag)
-	if password == "" {
-		fmt.Println("Password:")
-		passwordByte, err := term.ReadPassword(0)
+	username, err := loginUsername(cmd)
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(cmd)
+	if err != nil {
+		return err
+	}
+
+	token, sessionID, err := loginToVManage(context.Background(), username, password)
+	if err != nil {
+		return err
+	}
+	values := sessionValues(token, sessionID, time.Now())
+	values[usernameFlag] = username
+	return saveConfig(values)
+}
+
+// loginUsername returns the username given with --username or the one in
+// the config.
+func loginUsername(cmd *cobra.Command) (string, error) {
+	if username := cmd.Flag(usernameNameFlag).Value.String(); username != "" {
+		return username, nil
+	}
+	if username := viper.GetString(usernameFlag); username != "" {
+		return username, nil
+	}
+	return "", fmt.Errorf("no username, set --%s or %s in the config", usernameNameFlag, usernameFlag)
+}
+
+// readPassword takes the password from standard input or a file when
+// requested by the flags, then from the environment, and finally asks the
+// user if standard input is a terminal.
+func readPassword(cmd *cobra.Command) (string, error) {
+	if fromStdin, _ := cmd.Flags().GetBool(passwordStdinFlag); fromStdin {
+		data, err := io.ReadAll(passwordInput)
+		if err != nil {
+			return "", fmt.Errorf("could not read password from stdin: %v", err)
+		}
+		return nonEmptyPassword(data, "stdin")
+	}
+	if path := cmd.Flag(passwordFileFlag).Value.String(); path != "" {
+		data, err := os.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("could not get password: %v", err)
+			return "", fmt.Errorf("could not read password file: %v", err)
 		}
-		password = string(passwordByte)
+		return nonEmptyPassword(data, path)
+	}
+	if password := os.Getenv(environmentVariableFlag); password != "" {
+		return password, nil
+	}
+	if !isTerminal() {
+		return "", fmt.Errorf("could not get password: stdin is not a terminal, use --%s, --%s or %s",
+			passwordStdinFlag, passwordFileFlag, environmentVariableFlag)
+	}
+	fmt.Println("Password:")
+	passwordByte, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", fmt.Errorf("could not get password: %v", err)
 	}
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
+	return string(passwordByte), nil
+}
+
+// nonEmptyPassword strips the trailing newline written by most tools.
+func nonEmptyPassword(data []byte, source string) (string, error) {
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("empty password in %s", source)
 	}
-	username := cmd.Flag(usernameNameFlag).Value.String()
+	return password, nil
+}
+
+// addPasswordFlags adds the flags used to log in to vManage.
+func addPasswordFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(usernameNameFlag, "u", "", "Username, defaults to "+usernameFlag+" in the config")
+	cmd.Flags().Bool(passwordStdinFlag, false, "Read the password from stdin")
+	cmd.Flags().String(passwordFileFlag, "", "Read the password from a file")
+	cmd.MarkFlagsMutuallyExclusive(passwordStdinFlag, passwordFileFlag)
+}
 
+// loginToVManage logs in to vManage and returns the token and the session
+// ID of the new session.
+func loginToVManage(ctx context.Context, username, password string) (string, string, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
-		return fmt.Errorf("could not create cookie jar: %v", err)
+		return "", "", fmt.Errorf("could not create cookie jar: %v", err)
 	}
 	client, err := getClientWithJar(jar)
 	if err != nil {
-		return fmt.Errorf("could not get client: %v", err)
+		return "", "", fmt.Errorf("could not get client: %v", err)
 	}
 
-	ctx := context.Background()
 	if err := client.Login(ctx, username, password); err != nil {
-		return fmt.Errorf("could not login: %v", err)
+		return "", "", fmt.Errorf("could not login: %v", err)
 	}
 
 	vManageURL := viper.GetString(urlFlag)
 	u, err := url.Parse(vManageURL)
 	if err != nil {
-		return fmt.Errorf("could not parse url: %v", u)
+		return "", "", fmt.Errorf("could not parse url: %v", u)
 	}
-	viper.Set(tokenFlag, client.GetToken())
-	viper.Set(sessionIDFlag, jar.Cookies(u)[0].Value)
-	if err := viper.WriteConfig(); err != nil {
-		return fmt.Errorf("could not write config: %v", err)
+	cookies := jar.Cookies(u)
+	if len(cookies) == 0 {
+		return "", "", fmt.Errorf("vManage did not return a session cookie")
 	}
-
-	return nil
+	return client.GetToken(), cookies[0].Value, nil
 }
 
 func init() {
 	rootCmd.AddCommand(generateTokenCmd)
-	generateTokenCmd.Flags().StringP(usernameNameFlag, "u", "", "Username")
-	_ = generateTokenCmd.MarkFlagRequired(usernameNameFlag)
+	addPasswordFlags(generateTokenCmd)
 }
@@ -0,0 +1,111 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPassword(t *testing.T) {
+	terminal := isTerminal
+	t.Cleanup(func() {
+		passwordInput, isTerminal = os.Stdin, terminal
+	})
+	isTerminal = func() bool { return false }
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("from-file\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		env      string
+		expected string
+		err      string
+	}{
+		{
+			name:     "stdin",
+			args:     []string{"--" + passwordStdinFlag},
+			stdin:    "from-stdin\r\n",
+			env:      "ignored",
+			expected: "from-stdin",
+		},
+		{
+			name:  "empty stdin",
+			args:  []string{"--" + passwordStdinFlag},
+			stdin: "\n",
+			err:   "empty password in stdin",
+		},
+		{
+			name:     "file",
+			args:     []string{"--" + passwordFileFlag, passwordFile},
+			expected: "from-file",
+		},
+		{
+			name:     "environment",
+			env:      "from-env",
+			expected: "from-env",
+		},
+		{
+			name: "not a terminal",
+			err:  "could not get password: stdin is not a terminal, use --password-stdin, --password-file or VMANAGE_PASSWORD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(environmentVariableFlag, tt.env)
+			passwordInput = strings.NewReader(tt.stdin)
+			cmd := &cobra.Command{}
+			addPasswordFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			password, err := readPassword(cmd)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, password)
+		})
+	}
+}
+
+func TestLoginUsername(t *testing.T) {
+	t.Cleanup(resetConfig)
+	cmd := &cobra.Command{}
+	addPasswordFlags(cmd)
+	_, err := loginUsername(cmd)
+	require.EqualError(t, err, "no username, set --username or controllers.sdwan.username in the config")
+
+	viper.Set(usernameFlag, "admin")
+	username, err := loginUsername(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "admin", username)
+
+	require.NoError(t, cmd.ParseFlags([]string{"-u", "operator"}))
+	username, err = loginUsername(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "operator", username)
+}
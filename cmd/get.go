@@ -17,7 +17,11 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
 
 const (
 	idFlag = "id"
@@ -32,4 +36,18 @@ var getCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.PersistentFlags().String(idFlag, "", "ID of resource")
+	getCmd.PersistentFlags().StringP(outputFlag, "o", jsonOutput,
+		"Output format: json, yaml, table, wide, csv, tsv, markdown, custom-columns=HEADER:.path,..., jsonpath=TEMPLATE or go-template=TEMPLATE")
+}
+
+// resourceID returns the ID or name of the resource to get, given as the
+// argument or with --id.
+func resourceID(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if id := cmd.Flag(idFlag).Value.String(); id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("missing ID or name of %s", cmd.Name())
 }
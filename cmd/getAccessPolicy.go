@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// getAccessPolicyCmd represents the get AccessPolicy command
+var getAccessPolicyCmd = &cobra.Command{
+	Use:   "access-policy [name]",
+	Short: "get Access Policy",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  getAccessPolicy,
+}
+
+func getAccessPolicy(cmd *cobra.Command, args []string) error {
+	name, err := resourceID(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	c, err := clients.securityPolicy()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+	// There is no RPC getting a single access policy.
+	logger.Infof("sending list AccessPolicies request")
+	policies, err := c.ListAccessPolicies(ctx, &awi.AccessPolicyListRequest{})
+	if err != nil {
+		return fmt.Errorf("could not get access policy: %v", err)
+	}
+	for _, policy := range policies.GetAccessPolicies() {
+		if policy.GetMetadata().GetName() == name {
+			display := convertAccessPolicies([]*awi.Security_AccessPolicy{policy})[0]
+			return printResource(cmd, policy, display, accessPolicyDisplays, accessPolicyManifestOf(policy))
+		}
+	}
+	return fmt.Errorf("access policy %q not found", name)
+}
+
+func init() {
+	getCmd.AddCommand(getAccessPolicyCmd)
+}
@@ -18,61 +18,46 @@
 package cmd
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
-	"google.golang.org/protobuf/encoding/protojson"
-
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
 )
 
 // getAppCmd represents the get AppConnection command
 var getAppCmd = &cobra.Command{
-	Use:   "app-connection",
+	Use:   "app-connection [id]",
 	Short: "get Application connection",
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  getApp,
 }
 
-func getApp(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
+func getApp(cmd *cobra.Command, args []string) error {
+	id, err := resourceID(cmd, args)
+	if err != nil {
+		return err
 	}
-	id := cmd.Flag(idFlag).Value.String()
 
 	// Set up a connection to the server.
-	conn, err := getGRPCClient()
+	cc, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := requestContext()
 	defer cancel()
 	logger.Infof("sending get AppConnection request")
-	cc := awi.NewAppConnectionControllerClient(conn)
 	response, err := cc.GetAppConnection(ctx, &awi.GetAppConnectionRequest{ConnectionId: id})
 	if err != nil {
 		return fmt.Errorf("could not get connection: %v", err)
 	}
 
-	b, err := protojson.Marshal(response.AppConnection)
-	if err != nil {
-		return err
-	}
-	var obj map[string]interface{}
-	err = json.Unmarshal(b, &obj)
-	if err != nil {
-		return err
-	}
-	d, err := json.MarshalIndent(obj, "", "    ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(d))
-	return nil
+	connection := response.GetAppConnection()
+	display := convertAppConnectionRequest([]*awi.AppConnectionInformation{connection}, prettyprint.TimestampsRFC3339, time.Now())[0]
+	return printResource(cmd, connection, display, appConnectionDisplays, appConnectionManifestOf(connection))
 }
 
 func init() {
@@ -18,12 +18,7 @@
 package cmd
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
-	"time"
-
-	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -31,48 +26,35 @@ import (
 
 // getAppPolicyCmd represents the get AppConnectionPolicy command
 var getAppPolicyCmd = &cobra.Command{
-	Use:   "app-connection-policy",
+	Use:   "app-connection-policy [id]",
 	Short: "get Application Connection Policy",
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  getAppPolicy,
 }
 
-func getAppPolicy(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
+func getAppPolicy(cmd *cobra.Command, args []string) error {
+	id, err := resourceID(cmd, args)
+	if err != nil {
+		return err
 	}
-	id := cmd.Flag(idFlag).Value.String()
 
 	// Set up a connection to the server.
-	conn, err := getGRPCClient()
+	cc, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := requestContext()
 	defer cancel()
 	logger.Infof("sending get AppConnectionPolicy request")
-	cc := awi.NewAppConnectionControllerClient(conn)
 	response, err := cc.GetAppConnectionPolicy(ctx, &awi.GetAppConnectionPolicyRequest{Id: id})
 	if err != nil {
 		return fmt.Errorf("could not get connection: %v", err)
 	}
 
-	b, err := protojson.Marshal(response.GetAppConnectionPolicy())
-	if err != nil {
-		return err
-	}
-	var obj map[string]interface{}
-	err = json.Unmarshal(b, &obj)
-	if err != nil {
-		return err
-	}
-	d, err := json.MarshalIndent(obj, "", "    ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(d))
-	return nil
+	policy := response.GetAppConnectionPolicy()
+	display := convertAppConnectionPolicyRequest([]*awi.AppConnectionPolicy{policy})[0]
+	return printResource(cmd, policy, display, appConnectionPolicyDisplays, appConnectionPolicyManifestOf(policy))
 }
 
 func init() {
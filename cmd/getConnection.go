@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+// getConnectionCmd represents the get Connection command
+var getConnectionCmd = &cobra.Command{
+	Use:   "connection [id|name]",
+	Short: "get Connection",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  getConnection,
+}
+
+func getConnection(cmd *cobra.Command, args []string) error {
+	id, err := resourceID(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	c, err := clients.connectionController()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+	// GetConnection only returns the status, so the connection is looked
+	// up in the list of connections.
+	logger.Infof("sending list Connections request")
+	connections, err := c.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not get connection: %v", err)
+	}
+	connection := findConnection(connections.GetConnections(), id)
+	if connection == nil {
+		return fmt.Errorf("connection %q not found", id)
+	}
+
+	display := convertConnectionRequest([]*awi.ConnectionInformation{connection}, prettyprint.TimestampsRFC3339, time.Now())[0]
+	return printResource(cmd, connection, display, connectionDisplays, connectionManifestOf(connection))
+}
+
+// findConnection returns the connection with the ID or, if there is none,
+// the name.
+func findConnection(connections []*awi.ConnectionInformation, id string) *awi.ConnectionInformation {
+	for _, connection := range connections {
+		if connection.GetId() == id {
+			return connection
+		}
+	}
+	for _, connection := range connections {
+		if connection.GetMetadata().GetName() == id {
+			return connection
+		}
+	}
+	return nil
+}
+
+func init() {
+	getCmd.AddCommand(getConnectionCmd)
+}
@@ -18,15 +18,9 @@
 package cmd
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
-	"time"
-
-	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/spf13/cobra"
-	awi "github.com/app-net-interface/awi-grpc/pb"
 )
 
 // getMatchedResourcesCmd represents the get Matched Resources command
@@ -37,47 +31,28 @@ var getMatchedResourcesCmd = &cobra.Command{
 }
 
 func getMatched(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
 	connectionConfigPath := cmd.Flag(connectionConfigFlag).Value.String()
 
 	// Set up a connection to the server.
-	conn, err := getGRPCClient()
+	cc, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
 
 	appConn, err := getAppConnectionConfigGRPC(connectionConfigPath)
 	if err != nil {
 		return fmt.Errorf("could not initialize app connection config: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := requestContext()
 	defer cancel()
 	logger.Infof("sending GetMatchedResources request")
-	cc := awi.NewAppConnectionControllerClient(conn)
 	response, err := cc.GetMatchedResources(ctx, appConn)
 	if err != nil {
 		return fmt.Errorf("could not get matched resources: %v", err)
 	}
 
-	b, err := protojson.Marshal(response)
-	if err != nil {
-		return err
-	}
-	var obj map[string]interface{}
-	err = json.Unmarshal(b, &obj)
-	if err != nil {
-		return err
-	}
-	d, err := json.MarshalIndent(obj, "", "    ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(d))
-	return nil
+	return printObject(cmd, response, nil)
 }
 
 func init() {
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+)
+
+// getNetworkSLACmd represents the get NetworkSLA command
+var getNetworkSLACmd = &cobra.Command{
+	Use:   "network-sla [name]",
+	Short: "get Network SLA",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  getNetworkSLA,
+}
+
+func getNetworkSLA(cmd *cobra.Command, args []string) error {
+	name, err := resourceID(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	c, err := clients.networkSLA()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+	// There is no RPC getting a single network SLA.
+	logger.Infof("sending list NetworkSLAs request")
+	slas, err := c.ListNetworkSLAs(ctx, &awi.NetworkSLAListReqest{})
+	if err != nil {
+		return fmt.Errorf("could not get network SLA: %v", err)
+	}
+	for _, sla := range slas.GetNetworkSLAs() {
+		if sla.GetMetadata().GetName() == name {
+			display := convertNetworksSLAs([]*awi.NetworkSLA{sla})[0]
+			return printResource(cmd, sla, display, networkSLADisplays, networkSLAManifestOf(sla))
+		}
+	}
+	return fmt.Errorf("network SLA %q not found", name)
+}
+
+func init() {
+	getCmd.AddCommand(getNetworkSLACmd)
+}
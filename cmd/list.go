@@ -18,7 +18,18 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
 )
 
 // listCmd represents the list command
@@ -28,10 +39,141 @@ var listCmd = &cobra.Command{
 }
 
 const (
-	outputFlag = "output"
+	outputFlag        = "output"
+	watchFlag         = "watch"
+	watchIntervalFlag = "watch-interval"
+	noHeadersFlag     = "no-headers"
+	sortByFlag        = "sort-by"
+	wrapFlag          = "wrap"
+	timestampsFlag    = "timestamps"
 )
 
 func init() {
 	rootCmd.AddCommand(listCmd)
-	listCmd.PersistentFlags().StringP(outputFlag, "o", "", "Format output")
+	listCmd.PersistentFlags().StringP(outputFlag, "o", "",
+		"Output format: table, wide, json, yaml, csv, tsv, markdown, custom-columns=HEADER:.path,..., jsonpath=TEMPLATE or go-template=TEMPLATE, a table by default")
+	listCmd.PersistentFlags().BoolP(watchFlag, "w", false, "Keep listing and highlight the rows which changed")
+	listCmd.PersistentFlags().Duration(watchIntervalFlag, 2*time.Second, "Interval between listings in watch mode")
+	listCmd.PersistentFlags().Bool(noHeadersFlag, false, "Do not print the header row of tables")
+	listCmd.PersistentFlags().String(sortByFlag, "", "Sort the rows of tables by the column with this header")
+	listCmd.PersistentFlags().Bool(wrapFlag, false, "Wrap values which do not fit in the terminal instead of truncating them")
+}
+
+// listTableOptions returns how the tables are rendered. Tables printed to
+// a terminal are fitted to its width, except in wide output.
+func listTableOptions(cmd *cobra.Command) (prettyprint.TableOptions, error) {
+	noHeaders, err := cmd.Flags().GetBool(noHeadersFlag)
+	if err != nil {
+		return prettyprint.TableOptions{}, err
+	}
+	wrap, err := cmd.Flags().GetBool(wrapFlag)
+	if err != nil {
+		return prettyprint.TableOptions{}, err
+	}
+	options := prettyprint.TableOptions{
+		NoHeaders: noHeaders,
+		SortBy:    cmd.Flag(sortByFlag).Value.String(),
+		Wrap:      wrap,
+	}
+	return terminalTableOptions(options, cmd.Flag(outputFlag).Value.String()), nil
+}
+
+// terminalTableOptions enables colors and, except in wide output, fits
+// tables to the width of the terminal when stdout is one.
+func terminalTableOptions(options prettyprint.TableOptions, format string) prettyprint.TableOptions {
+	fd := int(os.Stdout.Fd())
+	if term.IsTerminal(fd) {
+		options.Color = os.Getenv("NO_COLOR") == ""
+		if width, _, err := term.GetSize(fd); err == nil && format != wideOutput {
+			options.Width = width
+		}
+	}
+	return options
+}
+
+// addTimestampsFlag adds the flag selecting the format of timestamps in
+// tables.
+func addTimestampsFlag(cmd *cobra.Command) {
+	cmd.Flags().String(timestampsFlag, prettyprint.TimestampsRFC3339,
+		"Format of timestamps in tables: "+strings.Join(prettyprint.TimestampFormats, ", "))
+}
+
+func timestampsFormat(cmd *cobra.Command) (string, error) {
+	format := cmd.Flag(timestampsFlag).Value.String()
+	for _, f := range prettyprint.TimestampFormats {
+		if format == f {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported timestamps format %q, use one of %s",
+		format, strings.Join(prettyprint.TimestampFormats, ", "))
+}
+
+// changingColumns returns the headers of the columns whose values change
+// between listings of unchanged resources: ages and relative timestamps.
+func changingColumns(cmd *cobra.Command) []string {
+	columns := []string{"AGE"}
+	if flag := cmd.Flags().Lookup(timestampsFlag); flag != nil && flag.Value.String() == prettyprint.TimestampsRelative {
+		columns = append(columns, "CREATE_TIME", "MOD_TIME")
+	}
+	return columns
+}
+
+// watchList runs list once or, with --watch, repeatedly until interrupted.
+// In watch mode the output is re-rendered with the changed rows
+// highlighted.
+func watchList(cmd *cobra.Command, list func() error) error {
+	options, err := listTableOptions(cmd)
+	if err != nil {
+		return err
+	}
+	watch, err := cmd.Flags().GetBool(watchFlag)
+	if err != nil {
+		return err
+	}
+	if !watch {
+		prettyprint.SetTableOptions(options)
+		return list()
+	}
+	// The Watcher highlights whole rows, which colored cells would end
+	// early, and tells columns apart by their position.
+	options.Color = false
+	prettyprint.SetTableOptions(options)
+	interval, err := cmd.Flags().GetDuration(watchIntervalFlag)
+	if err != nil {
+		return err
+	}
+	// Rows are told apart by their first column, which needs the header
+	// and one line per row.
+	noHeaders, _ := cmd.Flags().GetBool(noHeadersFlag)
+	wrap, _ := cmd.Flags().GetBool(wrapFlag)
+	table := prettyprint.IsTable(cmd.Flag(outputFlag).Value.String()) && !noHeaders && !wrap
+	terminal := term.IsTerminal(int(os.Stdout.Fd()))
+	watcher := &prettyprint.Watcher{
+		Out:    os.Stdout,
+		Redraw: terminal,
+		Color:  terminal && os.Getenv("NO_COLOR") == "",
+		Ignore: changingColumns(cmd),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	defer prettyprint.SetOutput(os.Stdout)
+	for first := true; ; first = false {
+		var buffer bytes.Buffer
+		prettyprint.SetOutput(&buffer)
+		if err := list(); err != nil {
+			if first {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			watcher.Update(buffer.String(), table)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
 }
@@ -18,10 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
 
@@ -35,29 +31,27 @@ var listAccessPolicyCmd = &cobra.Command{
 	RunE:  listAccessPolicy,
 }
 
+// accessPolicyDisplays are the columns of access policy tables.
+var accessPolicyDisplays = []prettyprint.Display{
+	{Name: "Name", Display: "NAME"},
+}
+
 func listAccessPolicy(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+	c, err := clients.securityPolicy()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
-	c := awi.NewSecurityPolicyServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	AccessPolicies, err := c.ListAccessPolicies(ctx, &awi.AccessPolicyListRequest{})
-	if err != nil {
-		return err
-	}
-
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintConvertedData(AccessPolicies.GetAccessPolicies(), convertAccessPolicies(AccessPolicies.GetAccessPolicies()), []prettyprint.Display{
-		{Name: "Name", Display: "NAME"},
-	}, printFormat)
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		AccessPolicies, err := c.ListAccessPolicies(ctx, &awi.AccessPolicyListRequest{})
+		if err != nil {
+			return err
+		}
 
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return printResources(printFormat, AccessPolicies.GetAccessPolicies(), convertAccessPolicies(AccessPolicies.GetAccessPolicies()), accessPolicyDisplays, accessPolicyManifestOf)
+	})
 }
 
 type AccessPolicyDisplay struct {
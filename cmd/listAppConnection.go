@@ -18,8 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -35,34 +33,37 @@ var listAppConnectionCmd = &cobra.Command{
 	RunE:  listAppConnection,
 }
 
+// appConnectionDisplays are the columns of app connection tables.
+var appConnectionDisplays = []prettyprint.Display{
+	{Name: "ID", Display: "ID"},
+	{Name: "Name", Display: "NAME"},
+	{Name: "NetworkDomainConnectionName", Display: "NETWORK_DOMAIN_CONNECTION_NAME"},
+	{Name: "CreationTimestamp", Display: "CREATE_TIME"},
+	{Name: "ModificationTimestamp", Display: "MOD_TIME"},
+	{Name: "Status", Display: "STATUS", Color: prettyprint.StatusColor},
+	{Name: "Age", Display: "AGE"},
+}
+
 func listAppConnection(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+	timestamps, err := timestampsFormat(cmd)
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
-	c := awi.NewAppConnectionControllerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	connections, err := c.ListConnectedApps(ctx, &awi.ListAppConnectionsRequest{})
+	c, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		connections, err := c.ListConnectedApps(ctx, &awi.ListAppConnectionsRequest{})
+		if err != nil {
+			return err
+		}
 
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintConvertedData(connections.AppConnections, convertAppConnectionRequest(connections.AppConnections), []prettyprint.Display{
-		{Name: "ID", Display: "ID"},
-		{Name: "Name", Display: "NAME"},
-		{Name: "NetworkDomainConnectionName", Display: "NETWORK_DOMAIN_CONNECTION_NAME"},
-		{Name: "CreationTimestamp", Display: "CREATE_TIME"},
-		{Name: "ModificationTimestamp", Display: "MOD_TIME"},
-		{Name: "Status", Display: "STATUS"},
-	}, printFormat)
-
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return printResources(printFormat, connections.AppConnections, convertAppConnectionRequest(connections.AppConnections, timestamps, time.Now()), appConnectionDisplays, appConnectionManifestOf)
+	})
 }
 
 type appReqDisplay struct {
@@ -72,18 +73,21 @@ type appReqDisplay struct {
 	Status                      string
 	ModificationTimestamp       string
 	CreationTimestamp           string
+	Age                         string
 }
 
-func convertAppConnectionRequest(crs []*awi.AppConnectionInformation) []appReqDisplay {
+func convertAppConnectionRequest(crs []*awi.AppConnectionInformation, timestamps string, now time.Time) []appReqDisplay {
 	displays := make([]appReqDisplay, 0, len(crs))
 	for _, request := range crs {
+		metadata := request.GetAppConnectionConfig().GetMetadata()
 		display := appReqDisplay{
 			ID:                          request.GetId(),
-			Name:                        request.GetAppConnectionConfig().GetMetadata().GetName(),
+			Name:                        metadata.GetName(),
 			NetworkDomainConnectionName: request.GetNetworkDomainConnectionName(),
 			Status:                      awi.Status_name[int32(request.Status)],
-			ModificationTimestamp:       request.GetAppConnectionConfig().GetMetadata().GetModificationTimestamp(),
-			CreationTimestamp:           request.GetAppConnectionConfig().GetMetadata().GetCreationTimestamp(),
+			ModificationTimestamp:       prettyprint.FormatTimestamp(metadata.GetModificationTimestamp(), timestamps, now),
+			CreationTimestamp:           prettyprint.FormatTimestamp(metadata.GetCreationTimestamp(), timestamps, now),
+			Age:                         prettyprint.FormatAge(metadata.GetCreationTimestamp(), now),
 		}
 		displays = append(displays, display)
 	}
@@ -92,4 +96,5 @@ func convertAppConnectionRequest(crs []*awi.AppConnectionInformation) []appReqDi
 
 func init() {
 	listCmd.AddCommand(listAppConnectionCmd)
+	addTimestampsFlag(listAppConnectionCmd)
 }
@@ -18,10 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
 
@@ -35,30 +31,29 @@ var listAppConnectionPolicyCmd = &cobra.Command{
 	RunE:  listAppConnectionPolicy,
 }
 
+// appConnectionPolicyDisplays are the columns of app connection policy
+// tables.
+var appConnectionPolicyDisplays = []prettyprint.Display{
+	{Name: "ID", Display: "ID"},
+	{Name: "Name", Display: "NAME"},
+}
+
 func listAppConnectionPolicy(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+	c, err := clients.appConnectionController()
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
-	c := awi.NewAppConnectionControllerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	connections, err := c.ListAppConnectionPolicies(ctx, &awi.ListAppConnectionPoliciesRequest{})
-	if err != nil {
-		return err
-	}
-
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintConvertedData(connections.AppConnectionPolicies, convertAppConnectionPolicyRequest(connections.AppConnectionPolicies), []prettyprint.Display{
-		{Name: "ID", Display: "ID"},
-		{Name: "Name", Display: "NAME"},
-	}, printFormat)
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		connections, err := c.ListAppConnectionPolicies(ctx, &awi.ListAppConnectionPoliciesRequest{})
+		if err != nil {
+			return err
+		}
 
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return printResources(printFormat, connections.AppConnectionPolicies, convertAppConnectionPolicyRequest(connections.AppConnectionPolicies), appConnectionPolicyDisplays, appConnectionPolicyManifestOf)
+	})
 }
 
 type appPolicyReqDisplay struct {
@@ -18,8 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -35,73 +33,86 @@ var listConnectionCmd = &cobra.Command{
 	RunE:  listConnection,
 }
 
+// connectionDisplays are the columns of connection tables.
+var connectionDisplays = []prettyprint.Display{
+	{Name: "ID", Display: "ID"},
+	{Name: "Name", Display: "NAME"},
+	{Name: "SourceName", Display: "SRC_NAME"},
+	{Name: "SourceID", Display: "SRC_ID", Wide: true},
+	{Name: "SourceType", Display: "SRC_TYPE"},
+	{Name: "SourceProvider", Display: "SRC_PROVIDER"},
+	{Name: "DestinationName", Display: "DEST_NAME"},
+	{Name: "DestinationID", Display: "DEST_ID", Wide: true},
+	{Name: "DestinationType", Display: "DEST_TYPE"},
+	{Name: "DestinationProvider", Display: "DEST_PROVIDER"},
+	//{Name: "DefaultAccess", Display: "DEFAULT_ACCESS"},
+	{Name: "CreationTimestamp", Display: "CREATE_TIME"},
+	{Name: "ModificationTimestamp", Display: "MOD_TIME"},
+	{Name: "Status", Display: "STATUS", Color: prettyprint.StatusColor},
+	{Name: "Age", Display: "AGE"},
+}
+
 func listConnection(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
+	timestamps, err := timestampsFormat(cmd)
 	if err != nil {
 		return err
 	}
-	defer connClose(conn)
-	c := awi.NewConnectionControllerClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	connections, err := c.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	c, err := clients.connectionController()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		connections, err := c.ListConnections(ctx, &awi.ListConnectionsRequest{})
+		if err != nil {
+			return err
+		}
 
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintConvertedData(connections.GetConnections(), convertConnectionRequest(connections.GetConnections()), []prettyprint.Display{
-		{Name: "ID", Display: "ID"},
-		{Name: "Name", Display: "NAME"},
-		{Name: "SourceName", Display: "SRC_NAME"},
-		{Name: "SourceType", Display: "SRC_TYPE"},
-		{Name: "SourceProvider", Display: "SRC_PROVIDER"},
-		{Name: "DestinationName", Display: "DEST_NAME"},
-		{Name: "DestinationType", Display: "DEST_TYPE"},
-		{Name: "DestinationProvider", Display: "DEST_PROVIDER"},
-		//{Name: "DefaultAccess", Display: "DEFAULT_ACCESS"},
-		{Name: "CreationTimestamp", Display: "CREATE_TIME"},
-		{Name: "ModificationTimestamp", Display: "MOD_TIME"},
-		{Name: "Status", Display: "STATUS"},
-	}, printFormat)
-
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return printResources(printFormat, connections.GetConnections(), convertConnectionRequest(connections.GetConnections(), timestamps, time.Now()), connectionDisplays, connectionManifestOf)
+	})
 }
 
 type reqDisplay struct {
 	ID                  string
 	Name                string
 	SourceName          string
+	SourceID            string
 	SourceType          string
 	SourceProvider      string
 	DestinationName     string
+	DestinationID       string
 	DestinationType     string
 	DestinationProvider string
 	//DefaultAccess         string
 	Status                string
 	ModificationTimestamp string
 	CreationTimestamp     string
+	Age                   string
 }
 
-func convertConnectionRequest(crs []*awi.ConnectionInformation) []reqDisplay {
+// convertConnectionRequest converts connections to table rows, printing
+// timestamps in the given format and ages relative to now.
+func convertConnectionRequest(crs []*awi.ConnectionInformation, timestamps string, now time.Time) []reqDisplay {
 	displays := make([]reqDisplay, 0, len(crs))
 	for _, conn := range crs {
 		display := reqDisplay{
 			ID:                  conn.GetId(),
 			Name:                conn.GetMetadata().GetName(),
 			SourceName:          conn.GetSource().GetName(),
+			SourceID:            conn.GetSource().GetId(),
 			SourceType:          conn.GetSource().GetType(),
 			SourceProvider:      conn.GetSource().GetProvider(),
 			DestinationName:     conn.GetDestination().GetName(),
+			DestinationID:       conn.GetDestination().GetId(),
 			DestinationType:     conn.GetDestination().GetType(),
 			DestinationProvider: conn.GetDestination().GetProvider(),
 			//DefaultAccess:         conn.GetDestination().GetDefaultAccessControl(),
 			Status:                awi.Status_name[int32(conn.GetStatus())],
-			ModificationTimestamp: conn.ModificationTimestamp,
-			CreationTimestamp:     conn.CreationTimestamp,
+			ModificationTimestamp: prettyprint.FormatTimestamp(conn.GetModificationTimestamp(), timestamps, now),
+			CreationTimestamp:     prettyprint.FormatTimestamp(conn.GetCreationTimestamp(), timestamps, now),
+			Age:                   prettyprint.FormatAge(conn.GetCreationTimestamp(), now),
 		}
 		displays = append(displays, display)
 	}
@@ -111,4 +122,5 @@ func convertConnectionRequest(crs []*awi.ConnectionInformation) []reqDisplay {
 func init() {
 	listCmd.AddCommand(listConnectionCmd)
 	listConnectionCmd.Flags().String(cloudFlag, "", "Cloud")
+	addTimestampsFlag(listConnectionCmd)
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+func TestConvertConnectionRequestAge(t *testing.T) {
+	connections := []*awi.ConnectionInformation{{
+		Id:                "connection-1",
+		Status:            awi.Status_SUCCESS,
+		CreationTimestamp: fakeNow.Format(time.RFC3339),
+	}}
+	now := fakeNow.Add(3*time.Hour + 12*time.Minute)
+
+	displays := convertConnectionRequest(connections, prettyprint.TimestampsRFC3339, now)
+	require.Equal(t, "3h12m", displays[0].Age)
+	require.Equal(t, fakeNow.Format(time.RFC3339), displays[0].CreationTimestamp)
+
+	displays = convertConnectionRequest(connections, prettyprint.TimestampsRelative, now)
+	require.Equal(t, "3h12m ago", displays[0].CreationTimestamp)
+	require.Equal(t, "", displays[0].ModificationTimestamp)
+}
+
+func TestWatchConnectionsUnchanged(t *testing.T) {
+	cmd := &cobra.Command{}
+	addTimestampsFlag(cmd)
+	require.NoError(t, cmd.Flags().Set(timestampsFlag, prettyprint.TimestampsRelative))
+	connections := []*awi.ConnectionInformation{{
+		Id:                    "connection-1",
+		Metadata:              &awi.ConnectionMetadata{Name: "web"},
+		Status:                awi.Status_SUCCESS,
+		CreationTimestamp:     fakeNow.Add(-time.Hour).Format(time.RFC3339),
+		ModificationTimestamp: fakeNow.Add(-time.Minute).Format(time.RFC3339),
+	}}
+
+	var out bytes.Buffer
+	watcher := &prettyprint.Watcher{Out: &out, Redraw: true, Color: true, Ignore: changingColumns(cmd)}
+	prettyprint.SetTableOptions(prettyprint.TableOptions{})
+	defer prettyprint.SetOutput(os.Stdout)
+	for _, now := range []time.Time{fakeNow, fakeNow.Add(2 * time.Minute)} {
+		out.Reset()
+		var buffer bytes.Buffer
+		prettyprint.SetOutput(&buffer)
+		displays := convertConnectionRequest(connections, prettyprint.TimestampsRelative, now)
+		require.NoError(t, prettyprint.PrintConvertedData(connections, displays, connectionDisplays, ""))
+		watcher.Update(buffer.String(), true)
+	}
+	require.Contains(t, out.String(), "1h2m ago")
+	require.NotContains(t, out.String(), "\033[3")
+}
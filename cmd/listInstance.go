@@ -18,10 +18,8 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
@@ -36,10 +34,6 @@ var listInstanceCmd = &cobra.Command{
 }
 
 func listInstance(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-
 	cloud := cmd.Flag(cloudFlag).Value.String()
 	printFormat := cmd.Flag(outputFlag).Value.String()
 	vpcID := cmd.Flag(vpcFlag).Value.String()
@@ -61,39 +55,37 @@ func listInstance(cmd *cobra.Command, _ []string) error {
 		showLabels = false
 	}
 
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := infrapb.NewCloudProviderServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &infrapb.ListInstancesRequest{
-		Zone:     zone,
-		VpcId:    vpcID,
-		Provider: cloud,
-		Labels:   labels,
-	}
-	instances, err := c.ListInstances(ctx, in)
+	c, err := clients.cloudProvider()
 	if err != nil {
 		return err
 	}
-	displays := []prettyprint.Display{
-		{Name: "Id", Display: "ID"},
-		{Name: "Name", Display: "NAME"},
-		{Name: "PublicIP", Display: "PUBLIC_IP"},
-		{Name: "PrivateIP", Display: "PRIVATE_IP"},
-		{Name: "SubnetID", Display: "SUBNET_ID"},
-		{Name: "VpcId", Display: "VPC_ID"},
-	}
-	if showLabels {
-		displays = append(displays, prettyprint.Display{Name: "Labels", Display: "LABELS"})
-	}
-
-	prettyprint.PrintData(instances.Instances, displays, printFormat)
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &infrapb.ListInstancesRequest{
+			Zone:     zone,
+			VpcId:    vpcID,
+			Provider: cloud,
+			Labels:   labels,
+		}
+		instances, err := c.ListInstances(ctx, in)
+		if err != nil {
+			return err
+		}
+		displays := []prettyprint.Display{
+			{Name: "Id", Display: "ID"},
+			{Name: "Name", Display: "NAME"},
+			{Name: "PublicIP", Display: "PUBLIC_IP"},
+			{Name: "PrivateIP", Display: "PRIVATE_IP"},
+			{Name: "SubnetID", Display: "SUBNET_ID"},
+			{Name: "VpcId", Display: "VPC_ID"},
+			{Name: "Zone", Display: "ZONE", Wide: true},
+			{Name: "State", Display: "STATE", Wide: true},
+			{Name: "Labels", Display: "LABELS", Wide: !showLabels},
+		}
 
-	return nil
+		return prettyprint.PrintData(instances.Instances, displays, printFormat)
+	})
 }
 
 func init() {
@@ -18,10 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
 	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
@@ -43,78 +39,69 @@ type networkDomain struct {
 }
 
 func listNetworkDomains(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
 	printFormat := cmd.Flag(outputFlag).Value.String()
 
-	var vpns []*awi.VPN
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	conn, err := getGRPCClient()
+	c, err := clients.cloud()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = conn.Close()
-	}()
-	c := awi.NewCloudClient(conn)
-	// list all VPNs
-	vpnsList, err := c.ListVPNs(ctx, &awi.ListVPNRequest{})
+	infraC, err := clients.cloudProvider()
 	if err != nil {
 		return err
 	}
-	vpns = vpnsList.VPNs
 
-	conn2, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn2)
-	infraC := infrapb.NewCloudProviderServiceClient(conn2)
+	return watchList(cmd, func() error {
+		var vpns []*awi.VPN
 
-	// list All VPCs
-	awsvpcs, err := infraC.ListVPC(ctx, &infrapb.ListVPCRequest{
-		Provider: "aws",
-	})
-	if err != nil {
-		return err
-	}
-	gcpvpcs, err := infraC.ListVPC(ctx, &infrapb.ListVPCRequest{
-		Provider: "gcp",
-	})
-	if err != nil {
-		return err
-	}
+		ctx, cancel := requestContext()
+		defer cancel()
 
-	networkDomains := make([]networkDomain, 0, len(vpns)+len(awsvpcs.Vpcs)+len(gcpvpcs.Vpcs))
-	for _, vpn := range vpns {
-		networkDomains = append(networkDomains, networkDomain{
-			Type:     "VRF",
-			Provider: "Cisco-SDWAN-vManage", // TODO should be based on info from VPN response message
-			ID:       vpn.SegmentID,
-			Name:     vpn.SegmentName,
+		// list all VPNs
+		vpnsList, err := c.ListVPNs(ctx, &awi.ListVPNRequest{})
+		if err != nil {
+			return err
+		}
+		vpns = vpnsList.VPNs
+
+		// list All VPCs
+		awsvpcs, err := infraC.ListVPC(ctx, &infrapb.ListVPCRequest{
+			Provider: "aws",
 		})
-	}
-	for _, vpc := range append(awsvpcs.Vpcs, gcpvpcs.Vpcs...) {
-		networkDomains = append(networkDomains, networkDomain{
-			Type:     "VPC",
-			Provider: vpc.Provider,
-			ID:       vpc.Id,
-			Name:     vpc.Name,
+		if err != nil {
+			return err
+		}
+		gcpvpcs, err := infraC.ListVPC(ctx, &infrapb.ListVPCRequest{
+			Provider: "gcp",
 		})
-	}
+		if err != nil {
+			return err
+		}
 
-	prettyprint.PrintData(networkDomains, []prettyprint.Display{
-		{Name: "Type", Display: "TYPE"},
-		{Name: "Provider", Display: "PROVIDER"},
-		{Name: "Name", Display: "NAME"},
-		{Name: "ID", Display: "ID"},
-	}, printFormat)
+		networkDomains := make([]networkDomain, 0, len(vpns)+len(awsvpcs.Vpcs)+len(gcpvpcs.Vpcs))
+		for _, vpn := range vpns {
+			networkDomains = append(networkDomains, networkDomain{
+				Type:     "VRF",
+				Provider: "Cisco-SDWAN-vManage", // TODO should be based on info from VPN response message
+				ID:       vpn.SegmentID,
+				Name:     vpn.SegmentName,
+			})
+		}
+		for _, vpc := range append(awsvpcs.Vpcs, gcpvpcs.Vpcs...) {
+			networkDomains = append(networkDomains, networkDomain{
+				Type:     "VPC",
+				Provider: vpc.Provider,
+				ID:       vpc.Id,
+				Name:     vpc.Name,
+			})
+		}
 
-	return nil
+		return prettyprint.PrintData(networkDomains, []prettyprint.Display{
+			{Name: "Type", Display: "TYPE"},
+			{Name: "Provider", Display: "PROVIDER"},
+			{Name: "Name", Display: "NAME"},
+			{Name: "ID", Display: "ID"},
+		}, printFormat)
+	})
 }
 
 func init() {
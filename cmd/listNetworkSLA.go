@@ -18,9 +18,7 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -35,36 +33,34 @@ var listNetworkSLACmd = &cobra.Command{
 	RunE:  listNetworkSLA,
 }
 
+// networkSLADisplays are the columns of network SLA tables.
+var networkSLADisplays = []prettyprint.Display{
+	{Name: "Name", Display: "NAME"},
+	{Name: "Description", Display: "DESCRIPTION"},
+	{Name: "Bandwidth", Display: "BANDWIDTH[Mbps]"},
+	{Name: "Jitter", Display: "JITTER[Ms]"},
+	{Name: "Latency", Display: "LATENCY[Ms]"},
+	{Name: "Loss", Display: "LOSS[%]"},
+	{Name: "Priority", Display: "PRIORITY"},
+	{Name: "EnforcementRequestType", Display: "ENFORCEMENT_REQUEST_TYPE"},
+}
+
 func listNetworkSLA(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := awi.NewNetworkSLAServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	networkSLAs, err := c.ListNetworkSLAs(ctx, &awi.NetworkSLAListReqest{})
+	c, err := clients.networkSLA()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		networkSLAs, err := c.ListNetworkSLAs(ctx, &awi.NetworkSLAListReqest{})
+		if err != nil {
+			return err
+		}
 
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintConvertedData(networkSLAs.GetNetworkSLAs(), convertNetworksSLAs(networkSLAs.GetNetworkSLAs()), []prettyprint.Display{
-		{Name: "Name", Display: "NAME"},
-		{Name: "Description", Display: "DESCRIPTION"},
-		{Name: "Bandwidth", Display: "BANDWIDTH[Mbps]"},
-		{Name: "Jitter", Display: "JITTER[Ms]"},
-		{Name: "Latency", Display: "LATENCY[Ms]"},
-		{Name: "Loss", Display: "LOSS[%]"},
-		{Name: "Priority", Display: "PRIORITY"},
-		{Name: "EnforcementRequestType", Display: "ENFORCEMENT_REQUEST_TYPE"},
-	}, printFormat)
-
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return printResources(printFormat, networkSLAs.GetNetworkSLAs(), convertNetworksSLAs(networkSLAs.GetNetworkSLAs()), networkSLADisplays, networkSLAManifestOf)
+	})
 }
 
 type networkSLADisplay struct {
@@ -18,10 +18,6 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
 
@@ -36,32 +32,27 @@ var listSiteCmd = &cobra.Command{
 }
 
 func listSite(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
 	printFormat := cmd.Flag(outputFlag).Value.String()
 
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := awi.NewCloudClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &awi.ListSiteRequest{}
-	sites, err := c.ListSites(ctx, in)
+	c, err := clients.cloud()
 	if err != nil {
 		return err
 	}
-	prettyprint.PrintData(sites.Sites, []prettyprint.Display{
-		{Name: "SiteID", Display: "SITE_ID"},
-		{Name: "Name", Display: "NAME"},
-		{Name: "IP", Display: "IP"},
-		{Name: "ID", Display: "ID"},
-	}, printFormat)
-
-	return nil
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &awi.ListSiteRequest{}
+		sites, err := c.ListSites(ctx, in)
+		if err != nil {
+			return err
+		}
+		return prettyprint.PrintData(sites.Sites, []prettyprint.Display{
+			{Name: "SiteID", Display: "SITE_ID"},
+			{Name: "Name", Display: "NAME"},
+			{Name: "IP", Display: "IP"},
+			{Name: "ID", Display: "ID"},
+		}, printFormat)
+	})
 }
 
 func init() {
@@ -18,10 +18,8 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"strings"
-	"time"
 	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
 
 	"github.com/spf13/cobra"
@@ -44,9 +42,6 @@ var listSubnetCmd = &cobra.Command{
 }
 
 func listSubnet(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
 	cloud := cmd.Flag(cloudFlag).Value.String()
 	printFormat := cmd.Flag(outputFlag).Value.String()
 	vpcID := cmd.Flag(vpcFlag).Value.String()
@@ -68,39 +63,37 @@ func listSubnet(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		showLabels = false
 	}
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := infrapb.NewCloudProviderServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &infrapb.ListSubnetsRequest{
-		Zone:     zone,
-		VpcId:    vpcID,
-		Provider: cloud,
-		Cidr:     cidr,
-		Labels:   labels,
-	}
-	subnets, err := c.ListSubnets(ctx, in)
+	c, err := clients.cloudProvider()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &infrapb.ListSubnetsRequest{
+			Zone:     zone,
+			VpcId:    vpcID,
+			Provider: cloud,
+			Cidr:     cidr,
+			Labels:   labels,
+		}
+		subnets, err := c.ListSubnets(ctx, in)
+		if err != nil {
+			return err
+		}
 
-	displays := []prettyprint.Display{
-		{Name: "SubnetId", Display: "SUBNET_ID"},
-		{Name: "Name", Display: "SUBNET_NAME"},
-		{Name: "VpcId", Display: "VPC_ID"},
-		{Name: "Zone", Display: "ZONE"},
-		{Name: "CidrBlock", Display: "CIDR_BLOCK"},
-	}
-	if showLabels {
-		displays = append(displays, prettyprint.Display{Name: "Labels", Display: "LABELS"})
-	}
-	prettyprint.PrintData(subnets.Subnets, displays, printFormat)
-
-	return nil
+		displays := []prettyprint.Display{
+			{Name: "SubnetId", Display: "SUBNET_ID"},
+			{Name: "Name", Display: "SUBNET_NAME"},
+			{Name: "VpcId", Display: "VPC_ID"},
+			{Name: "Zone", Display: "ZONE"},
+			{Name: "CidrBlock", Display: "CIDR_BLOCK"},
+			{Name: "Region", Display: "REGION", Wide: true},
+			{Name: "AccountId", Display: "ACCOUNT_ID", Wide: true},
+			{Name: "Labels", Display: "LABELS", Wide: !showLabels},
+		}
+		return prettyprint.PrintData(subnets.Subnets, displays, printFormat)
+	})
 }
 
 func init() {
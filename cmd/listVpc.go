@@ -18,10 +18,7 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
@@ -43,36 +40,33 @@ var listVPCCmd = &cobra.Command{
 }
 
 func listVPC(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := infrapb.NewCloudProviderServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &infrapb.ListVPCRequest{
-		Provider:  strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
-		Region:    cmd.Flag(regionFlag).Value.String(),
-		AccountId: cmd.Flag(accountIDFlag).Value.String(),
-	}
-	vpcs, err := c.ListVPC(ctx, in)
+	c, err := clients.cloudProvider()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &infrapb.ListVPCRequest{
+			Provider:  strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
+			Region:    cmd.Flag(regionFlag).Value.String(),
+			AccountId: cmd.Flag(accountIDFlag).Value.String(),
+		}
+		vpcs, err := c.ListVPC(ctx, in)
+		if err != nil {
+			return err
+		}
 
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintData(vpcs.Vpcs, []prettyprint.Display{
-		{Name: "Name", Display: "NAME"},
-		{Name: "Region", Display: "REGION"},
-		{Name: "Id", Display: "ID"},
-		{Name: "AccountId", Display: "ACCOUNT_ID"},
-	}, printFormat)
-
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return prettyprint.PrintData(vpcs.Vpcs, []prettyprint.Display{
+			{Name: "Name", Display: "NAME"},
+			{Name: "Region", Display: "REGION"},
+			{Name: "Id", Display: "ID"},
+			{Name: "AccountId", Display: "ACCOUNT_ID"},
+			{Name: "Provider", Display: "PROVIDER", Wide: true},
+			{Name: "Labels", Display: "LABELS", Wide: true},
+		}, printFormat)
+	})
 }
 
 func init() {
@@ -18,10 +18,7 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	awi "github.com/app-net-interface/awi-grpc/pb"
@@ -41,37 +38,32 @@ var listVPCTagCmd = &cobra.Command{
 }
 
 func listVpcTag(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := awi.NewCloudClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &awi.ListVPCTagRequest{
-		Provider: strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
-		Region:   cmd.Flag(regionFlag).Value.String(),
-		Tag:      cmd.Flag(tagNameFlag).Value.String(),
-	}
-	vpcs, err := c.ListVPCTags(ctx, in)
+	c, err := clients.cloud()
 	if err != nil {
 		return err
 	}
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &awi.ListVPCTagRequest{
+			Provider: strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
+			Region:   cmd.Flag(regionFlag).Value.String(),
+			Tag:      cmd.Flag(tagNameFlag).Value.String(),
+		}
+		vpcs, err := c.ListVPCTags(ctx, in)
+		if err != nil {
+			return err
+		}
 
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintData(vpcs.VPCs, []prettyprint.Display{
-		{Name: "Name", Display: "NAME"},
-		{Name: "Tag", Display: "TAG"},
-		{Name: "Region", Display: "REGION"},
-		{Name: "ID", Display: "ID"},
-		{Name: "AccountName", Display: "ACCOUNT_NAME"},
-	}, printFormat)
-
-	return nil
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return prettyprint.PrintData(vpcs.VPCs, []prettyprint.Display{
+			{Name: "Name", Display: "NAME"},
+			{Name: "Tag", Display: "TAG"},
+			{Name: "Region", Display: "REGION"},
+			{Name: "ID", Display: "ID"},
+			{Name: "AccountName", Display: "ACCOUNT_NAME"},
+		}, printFormat)
+	})
 }
 
 func init() {
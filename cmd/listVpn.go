@@ -18,10 +18,7 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"strings"
-	"time"
 
 	awi "github.com/app-net-interface/awi-grpc/pb"
 	"github.com/spf13/cobra"
@@ -37,32 +34,27 @@ var listVPNCmd = &cobra.Command{
 }
 
 func listVPN(cmd *cobra.Command, _ []string) error {
-	if err := initConfig(cmd.Flag(configFlag).Value.String()); err != nil {
-		return fmt.Errorf("could not initialize config: %v", err)
-	}
-	conn, err := getGRPCClient()
-	if err != nil {
-		return err
-	}
-	defer connClose(conn)
-	c := awi.NewCloudClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	in := &awi.ListVPNRequest{
-		Provider: strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
-	}
-	vpns, err := c.ListVPNs(ctx, in)
+	c, err := clients.cloud()
 	if err != nil {
 		return err
 	}
-	printFormat := cmd.Flag(outputFlag).Value.String()
-	prettyprint.PrintData(vpns.VPNs, []prettyprint.Display{
-		{Name: "SegmentID", Display: "SEGMENT_ID"},
-		{Name: "SegmentName", Display: "SEGMENT_NAME"},
-		{Name: "ID", Display: "ID"},
-	}, printFormat)
-
-	return nil
+	return watchList(cmd, func() error {
+		ctx, cancel := requestContext()
+		defer cancel()
+		in := &awi.ListVPNRequest{
+			Provider: strings.ToUpper(cmd.Flag(cloudFlag).Value.String()),
+		}
+		vpns, err := c.ListVPNs(ctx, in)
+		if err != nil {
+			return err
+		}
+		printFormat := cmd.Flag(outputFlag).Value.String()
+		return prettyprint.PrintData(vpns.VPNs, []prettyprint.Display{
+			{Name: "SegmentID", Display: "SEGMENT_ID"},
+			{Name: "SegmentName", Display: "SEGMENT_NAME"},
+			{Name: "ID", Display: "ID"},
+		}, printFormat)
+	})
 }
 
 func init() {
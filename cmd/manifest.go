@@ -0,0 +1,537 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindFlag      = "kind"
+	recursiveFlag = "recursive"
+	stdinPath     = "-"
+
+	connectionKind    = "InterNetworkDomainConnection"
+	appConnectionKind = "InterNetworkDomainAppConnection"
+	accessPolicyKind  = "accessPolicy"
+	networkSLAKind    = "networkSLA"
+)
+
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// manifestResource is a single AWI object read from a manifest together
+// with the operations needed to manage it declaratively.
+type manifestResource interface {
+	kind() string
+	name() string
+	// find looks up the live object with the same name and returns its
+	// ID. An empty ID means that the object does not exist yet.
+	find(ctx context.Context, conn *grpc.ClientConn) (string, proto.Message, error)
+	// desired returns the object described by the manifest in the form
+	// in which it is compared with the live object.
+	desired() proto.Message
+	// actual returns the part of the live object returned by find which
+	// corresponds to desired.
+	actual(live proto.Message) proto.Message
+	create(ctx context.Context, conn *grpc.ClientConn) (awi.Status, error)
+	delete(ctx context.Context, conn *grpc.ClientConn, id string) error
+}
+
+// document is a single YAML document read from a manifest file.
+type document struct {
+	file string
+	// source identifies the document in messages as file:line.
+	source string
+	// node is the root mapping of the document, used to report positions
+	// of individual fields.
+	node *yaml.Node
+	*viper.Viper
+}
+
+// readDocuments reads all documents from the given path. The path may point
+// to a file, a directory containing YAML or JSON files or be "-" to read
+// from the standard input. Files may contain several documents separated
+// by "---".
+func readDocuments(path string, recursive bool) ([]document, error) {
+	if path == "" {
+		return nil, fmt.Errorf("specify manifest file, directory or %q for standard input", stdinPath)
+	}
+	files, err := manifestFiles(path, recursive)
+	if err != nil {
+		return nil, err
+	}
+	var documents []document
+	for _, file := range files {
+		fileDocuments, err := readFileDocuments(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %v", file, err)
+		}
+		documents = append(documents, fileDocuments...)
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("no documents found in %s", path)
+	}
+	return documents, nil
+}
+
+// readDocument reads a file that is expected to hold exactly one document.
+func readDocument(path string) (document, error) {
+	documents, err := readDocuments(path, false)
+	if err != nil {
+		return document{}, err
+	}
+	if len(documents) > 1 {
+		return document{}, fmt.Errorf("expected a single document in %s, found %d", path, len(documents))
+	}
+	return documents[0], nil
+}
+
+func manifestFiles(path string, recursive bool) ([]string, error) {
+	if path == stdinPath {
+		return []string{path}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isManifestFile(entry.Name()) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no manifests found in %s", path)
+	}
+	return files, nil
+}
+
+func isManifestFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range manifestExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func readFileDocuments(path string) ([]document, error) {
+	if path == stdinPath {
+		return decodeDocuments(os.Stdin, "<stdin>")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodeDocuments(file, path)
+}
+
+func decodeDocuments(reader io.Reader, name string) ([]document, error) {
+	var documents []document
+	decoder := yaml.NewDecoder(reader)
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		source := fmt.Sprintf("%s:%d", name, node.Line)
+		var content map[string]interface{}
+		if err := node.Decode(&content); err != nil {
+			return nil, fmt.Errorf("%s: document is not a mapping: %v", source, err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+		v := viper.New()
+		if err := v.MergeConfigMap(content); err != nil {
+			return nil, fmt.Errorf("%s: %v", source, err)
+		}
+		documents = append(documents, document{
+			file:   name,
+			source: source,
+			node:   node.Content[0],
+			Viper:  v,
+		})
+	}
+	return documents, nil
+}
+
+// processDocuments calls process for every document and reports the
+// result of each of them, so that a single broken document does not stop
+// the remaining ones from being processed.
+func processDocuments(documents []document, process func(document) error) error {
+	failed := 0
+	for _, doc := range documents {
+		if len(documents) > 1 {
+			fmt.Printf("%s:\n", doc.source)
+		}
+		if err := process(doc); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", doc.source, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d documents failed", failed, len(documents))
+	}
+	return nil
+}
+
+// checkKind returns an error if the document declares a kind different
+// than the expected one. Documents without kind are accepted.
+func checkKind(doc document, expected string) error {
+	if kind := doc.GetString(kindFlag); kind != "" && !strings.EqualFold(kind, expected) {
+		return fmt.Errorf("expected %s, got %s", expected, kind)
+	}
+	return nil
+}
+
+// manifestKind returns the kind of the manifest. Manifests written before
+// kinds were introduced are recognized by their top level key.
+func manifestKind(v *viper.Viper) string {
+	if kind := v.GetString(kindFlag); kind != "" {
+		return kind
+	}
+	switch {
+	case v.IsSet(accessRequestFlag):
+		return appConnectionKind
+	case v.IsSet(networkSLAFlag):
+		return networkSLAKind
+	}
+	return ""
+}
+
+func newManifestResource(v *viper.Viper) (manifestResource, error) {
+	kind := manifestKind(v)
+	var resource manifestResource
+	switch strings.ToLower(kind) {
+	case strings.ToLower(connectionKind):
+		request, err := decodeConnectionConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		resource = &connectionManifest{request: request}
+	case strings.ToLower(appConnectionKind):
+		appConnection, err := decodeAppConnectionConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		resource = &appConnectionManifest{appConnection: appConnection}
+	case strings.ToLower(accessPolicyKind):
+		policy, err := decodeAccessControlConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("missing access policy spec")
+		}
+		resource = &accessPolicyManifest{policy: policy}
+	case strings.ToLower(networkSLAKind):
+		sla, err := decodeNetworkSLAConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		if sla == nil {
+			return nil, fmt.Errorf("missing %s definition", networkSLAFlag)
+		}
+		resource = &networkSLAManifest{sla: sla}
+	case "":
+		return nil, fmt.Errorf("missing kind")
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", kind)
+	}
+	if resource.name() == "" {
+		return nil, fmt.Errorf("%s has no name", resource.kind())
+	}
+	return resource, nil
+}
+
+// equalManifest reports whether the live object matches the manifest,
+// ignoring fields populated by the controller.
+func equalManifest(m manifestResource, live proto.Message) bool {
+	return proto.Equal(normalize(m.desired()), normalize(m.actual(live)))
+}
+
+// timestampFields are populated by the controller, together with the
+// top-level status, and ignored when manifests are compared with live objects.
+var timestampFields = []protoreflect.Name{"creationTimestamp", "modificationTimestamp"}
+
+// normalize returns a copy of the message without server populated fields.
+func normalize(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	clearServerFields(m.ProtoReflect())
+	return m
+}
+
+// clearServerFields clears the top-level status and the timestamps of the
+// message and its metadata. Nested fields sharing those names are kept.
+func clearServerFields(m protoreflect.Message) {
+	clearFields(m, "status")
+	clearFields(m, timestampFields...)
+	fd := m.Descriptor().Fields().ByName("metadata")
+	if fd != nil && fd.Message() != nil && m.Has(fd) {
+		clearFields(m.Mutable(fd).Message(), timestampFields...)
+	}
+}
+
+func clearFields(m protoreflect.Message, names ...protoreflect.Name) {
+	for _, name := range names {
+		if fd := m.Descriptor().Fields().ByName(name); fd != nil {
+			m.Clear(fd)
+		}
+	}
+}
+
+type connectionManifest struct {
+	request *awi.ConnectionRequest
+}
+
+func (m *connectionManifest) kind() string {
+	return connectionKind
+}
+
+func (m *connectionManifest) name() string {
+	return m.request.GetMetadata().GetName()
+}
+
+func (m *connectionManifest) find(ctx context.Context, conn *grpc.ClientConn) (string, proto.Message, error) {
+	c := awi.NewConnectionControllerClient(conn)
+	connections, err := c.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, connection := range connections.GetConnections() {
+		if connection.GetMetadata().GetName() == m.name() {
+			return connection.GetId(), connection, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func (m *connectionManifest) desired() proto.Message {
+	return m.request.GetSpec()
+}
+
+func (m *connectionManifest) actual(live proto.Message) proto.Message {
+	connection, _ := live.(*awi.ConnectionInformation)
+	return connection.GetConfig()
+}
+
+func (m *connectionManifest) create(ctx context.Context, conn *grpc.ClientConn) (awi.Status, error) {
+	c := awi.NewConnectionControllerClient(conn)
+	response, err := c.Connect(ctx, m.request)
+	if err != nil {
+		return 0, err
+	}
+	return response.GetStatus(), nil
+}
+
+func (m *connectionManifest) delete(ctx context.Context, conn *grpc.ClientConn, id string) error {
+	c := awi.NewConnectionControllerClient(conn)
+	_, err := c.Disconnect(ctx, &awi.DisconnectRequest{ConnectionId: id})
+	return err
+}
+
+type appConnectionManifest struct {
+	appConnection *awi.AppConnection
+}
+
+func (m *appConnectionManifest) kind() string {
+	return appConnectionKind
+}
+
+func (m *appConnectionManifest) name() string {
+	return m.appConnection.GetMetadata().GetName()
+}
+
+func (m *appConnectionManifest) find(ctx context.Context, conn *grpc.ClientConn) (string, proto.Message, error) {
+	c := awi.NewAppConnectionControllerClient(conn)
+	connections, err := c.ListConnectedApps(ctx, &awi.ListAppConnectionsRequest{})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, connection := range connections.GetAppConnections() {
+		if connection.GetAppConnectionConfig().GetMetadata().GetName() == m.name() {
+			return connection.GetId(), connection, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func (m *appConnectionManifest) desired() proto.Message {
+	return m.appConnection
+}
+
+func (m *appConnectionManifest) actual(live proto.Message) proto.Message {
+	switch live := live.(type) {
+	case *awi.AppConnectionInformation:
+		return live.GetAppConnectionConfig()
+	case *awi.AppConnectionPolicy:
+		return live.GetAppConnection()
+	}
+	return (*awi.AppConnection)(nil)
+}
+
+func (m *appConnectionManifest) create(ctx context.Context, conn *grpc.ClientConn) (awi.Status, error) {
+	c := awi.NewAppConnectionControllerClient(conn)
+	response, err := c.ConnectApps(ctx, m.appConnection)
+	if err != nil {
+		return 0, err
+	}
+	return response.GetStatus(), nil
+}
+
+func (m *appConnectionManifest) delete(ctx context.Context, conn *grpc.ClientConn, id string) error {
+	c := awi.NewAppConnectionControllerClient(conn)
+	_, err := c.DisconnectApps(ctx, &awi.AppDisconnectionRequest{ConnectionId: id})
+	return err
+}
+
+type accessPolicyManifest struct {
+	policy *awi.Security_AccessPolicy
+}
+
+func (m *accessPolicyManifest) kind() string {
+	return accessPolicyKind
+}
+
+func (m *accessPolicyManifest) name() string {
+	return m.policy.GetMetadata().GetName()
+}
+
+func (m *accessPolicyManifest) find(ctx context.Context, conn *grpc.ClientConn) (string, proto.Message, error) {
+	c := awi.NewSecurityPolicyServiceClient(conn)
+	policies, err := c.ListAccessPolicies(ctx, &awi.AccessPolicyListRequest{})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, policy := range policies.GetAccessPolicies() {
+		if policy.GetMetadata().GetName() == m.name() {
+			return policy.GetMetadata().GetName(), policy, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func (m *accessPolicyManifest) desired() proto.Message {
+	return m.policy
+}
+
+func (m *accessPolicyManifest) actual(live proto.Message) proto.Message {
+	policy, _ := live.(*awi.Security_AccessPolicy)
+	return policy
+}
+
+func (m *accessPolicyManifest) create(ctx context.Context, conn *grpc.ClientConn) (awi.Status, error) {
+	c := awi.NewSecurityPolicyServiceClient(conn)
+	response, err := c.CreateAccessPolicy(ctx, &awi.AccessPolicyCreateRequest{AccessPolicy: m.policy})
+	if err != nil {
+		return 0, err
+	}
+	return response.GetStatus(), nil
+}
+
+func (m *accessPolicyManifest) delete(ctx context.Context, conn *grpc.ClientConn, id string) error {
+	c := awi.NewSecurityPolicyServiceClient(conn)
+	_, err := c.DeleteAccessPolicy(ctx, &awi.AccessPolicyDeleteRequest{Name: id})
+	return err
+}
+
+type networkSLAManifest struct {
+	sla *awi.NetworkSLA
+}
+
+func (m *networkSLAManifest) kind() string {
+	return networkSLAKind
+}
+
+func (m *networkSLAManifest) name() string {
+	return m.sla.GetMetadata().GetName()
+}
+
+func (m *networkSLAManifest) find(ctx context.Context, conn *grpc.ClientConn) (string, proto.Message, error) {
+	c := awi.NewNetworkSLAServiceClient(conn)
+	slas, err := c.ListNetworkSLAs(ctx, &awi.NetworkSLAListReqest{})
+	if err != nil {
+		return "", nil, err
+	}
+	for _, sla := range slas.GetNetworkSLAs() {
+		if sla.GetMetadata().GetName() == m.name() {
+			return sla.GetMetadata().GetName(), sla, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func (m *networkSLAManifest) desired() proto.Message {
+	return m.sla
+}
+
+func (m *networkSLAManifest) actual(live proto.Message) proto.Message {
+	sla, _ := live.(*awi.NetworkSLA)
+	return sla
+}
+
+func (m *networkSLAManifest) create(ctx context.Context, conn *grpc.ClientConn) (awi.Status, error) {
+	c := awi.NewNetworkSLAServiceClient(conn)
+	response, err := c.CreateNetworkSLA(ctx, m.sla)
+	if err != nil {
+		return 0, err
+	}
+	return response.GetStatus(), nil
+}
+
+func (m *networkSLAManifest) delete(ctx context.Context, conn *grpc.ClientConn, id string) error {
+	c := awi.NewNetworkSLAServiceClient(conn)
+	_, err := c.DeleteNetworkSLA(ctx, &awi.NetworkSLADeleteRequest{Name: id})
+	return err
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const multiDocumentManifest = `apiVersion: awi.app-net-interface.io/v1alpha1
+kind: accessPolicy
+spec:
+  metadata:
+    name: policy-1
+  accessType: allow
+---
+# empty documents are skipped
+---
+appConnection:
+  metadata:
+    name: app-1
+  from:
+    endpoint:
+      selector:
+        matchLabels:
+          env: staging
+---
+networkSLA:
+  metadata:
+    name: sla-1
+  priority: high
+`
+
+func TestReadDocuments(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	require.NoError(t, os.Mkdir(nested, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "all.yaml"), []byte(multiDocumentManifest), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "sla.yml"), []byte("networkSLA:\n  metadata:\n    name: sla-2\n"), 0o600))
+
+	documents, err := readDocuments(dir, false)
+	require.NoError(t, err)
+	require.Len(t, documents, 3)
+
+	sources := make([]string, 0, len(documents))
+	names := make([]string, 0, len(documents))
+	for _, doc := range documents {
+		resource, err := newManifestResource(doc.Viper)
+		require.NoError(t, err)
+		sources = append(sources, doc.source)
+		names = append(names, resource.kind()+"/"+resource.name())
+	}
+	allPath := filepath.Join(dir, "all.yaml")
+	require.Equal(t, []string{allPath + ":1", allPath + ":9", allPath + ":18"}, sources)
+	require.Equal(t, []string{"accessPolicy/policy-1", "InterNetworkDomainAppConnection/app-1", "networkSLA/sla-1"}, names)
+
+	documents, err = readDocuments(dir, true)
+	require.NoError(t, err)
+	require.Len(t, documents, 4)
+
+	_, err = readDocument(allPath)
+	require.Error(t, err)
+}
+
+func TestNewManifestResourceErrors(t *testing.T) {
+	for manifest, expected := range map[string]string{
+		"metadata:\n  name: x\n":                     "missing kind",
+		"kind: Unknown\n":                            `unsupported kind "Unknown"`,
+		"kind: accessPolicy\nspec:\n  accessType: a": "accessPolicy has no name",
+	} {
+		documents, err := decodeDocuments(strings.NewReader(manifest), "test")
+		require.NoError(t, err)
+		require.Len(t, documents, 1)
+		_, err = newManifestResource(documents[0].Viper)
+		require.EqualError(t, err, expected)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/app-net-interface/awi-cli/prettyprint"
+)
+
+const (
+	jsonOutput = "json"
+	yamlOutput = "yaml"
+	wideOutput = "wide"
+
+	manifestAPIVersion = "awi.app-net-interface.io/v1alpha1"
+	statusField        = "status"
+)
+
+// resourceManifest is a live object printed in the form of the manifest it
+// can be created from. Fields populated by the controller are moved to
+// status, which is ignored when the manifest is read back.
+type resourceManifest struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	Spec       json.RawMessage `json:"spec,omitempty"`
+	NetworkSLA json.RawMessage `json:"networkSLA,omitempty"`
+	Status     *resourceStatus `json:"status,omitempty"`
+}
+
+type resourceStatus struct {
+	ID                          string          `json:"id,omitempty"`
+	Status                      string          `json:"status,omitempty"`
+	NetworkDomainConnectionName string          `json:"networkDomainConnectionName,omitempty"`
+	Source                      json.RawMessage `json:"source,omitempty"`
+	Destination                 json.RawMessage `json:"destination,omitempty"`
+	SourceMatched               json.RawMessage `json:"sourceMatched,omitempty"`
+	DestinationMatched          json.RawMessage `json:"destinationMatched,omitempty"`
+	CreationTimestamp           string          `json:"creationTimestamp,omitempty"`
+	ModificationTimestamp       string          `json:"modificationTimestamp,omitempty"`
+}
+
+// protoJSON encodes the message with protojson, returning nil for unset
+// messages so that they are omitted from the manifest.
+func protoJSON(m proto.Message) json.RawMessage {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		logger.Warnf("could not encode %s: %v", m.ProtoReflect().Descriptor().FullName(), err)
+		return nil
+	}
+	return b
+}
+
+// nameMetadata returns the manifest metadata of an object known by name.
+func nameMetadata(name string) json.RawMessage {
+	return protoJSON(&awi.ConnectionMetadata{Name: name})
+}
+
+// printResources prints the live objects as manifests in YAML output and
+// as before in every other format.
+func printResources[T, C any](format string, data []T, convertedData []C, displays []prettyprint.Display,
+	toManifest func(T) resourceManifest) error {
+	if format != yamlOutput {
+		return prettyprint.PrintConvertedData(data, convertedData, displays, format)
+	}
+	manifests := make([]resourceManifest, 0, len(data))
+	for _, d := range data {
+		manifests = append(manifests, toManifest(d))
+	}
+	prettyprint.PrintDocuments(manifests)
+	return nil
+}
+
+func connectionManifestOf(connection *awi.ConnectionInformation) resourceManifest {
+	return resourceManifest{
+		APIVersion: manifestAPIVersion,
+		Kind:       connectionKind,
+		Metadata:   protoJSON(connection.GetMetadata()),
+		Spec:       protoJSON(normalize(connection.GetConfig())),
+		Status: &resourceStatus{
+			ID:                    connection.GetId(),
+			Status:                connection.GetStatus().String(),
+			Source:                protoJSON(connection.GetSource()),
+			Destination:           protoJSON(connection.GetDestination()),
+			CreationTimestamp:     connection.GetCreationTimestamp(),
+			ModificationTimestamp: connection.GetModificationTimestamp(),
+		},
+	}
+}
+
+func appConnectionManifestOf(connection *awi.AppConnectionInformation) resourceManifest {
+	config := connection.GetAppConnectionConfig()
+	return resourceManifest{
+		APIVersion: manifestAPIVersion,
+		Kind:       appConnectionKind,
+		Metadata:   nameMetadata(config.GetMetadata().GetName()),
+		Spec:       appConnectionSpec(config),
+		Status: &resourceStatus{
+			ID:                          connection.GetId(),
+			Status:                      connection.GetStatus().String(),
+			NetworkDomainConnectionName: connection.GetNetworkDomainConnectionName(),
+			SourceMatched:               protoJSON(connection.GetSourceMatched()),
+			DestinationMatched:          protoJSON(connection.GetDestinationMatched()),
+			CreationTimestamp:           config.GetMetadata().GetCreationTimestamp(),
+			ModificationTimestamp:       config.GetMetadata().GetModificationTimestamp(),
+		},
+	}
+}
+
+func appConnectionPolicyManifestOf(policy *awi.AppConnectionPolicy) resourceManifest {
+	return resourceManifest{
+		APIVersion: manifestAPIVersion,
+		Kind:       appConnectionKind,
+		Metadata:   nameMetadata(policy.GetAppConnection().GetMetadata().GetName()),
+		Spec:       appConnectionSpec(policy.GetAppConnection()),
+		Status:     &resourceStatus{ID: policy.GetId()},
+	}
+}
+
+func appConnectionSpec(appConnection *awi.AppConnection) json.RawMessage {
+	spec, err := json.Marshal(map[string]json.RawMessage{
+		accessRequestFlag: protoJSON(normalize(appConnection)),
+	})
+	if err != nil {
+		return nil
+	}
+	return spec
+}
+
+func accessPolicyManifestOf(policy *awi.Security_AccessPolicy) resourceManifest {
+	return resourceManifest{
+		APIVersion: manifestAPIVersion,
+		Kind:       accessPolicyKind,
+		Metadata:   nameMetadata(policy.GetMetadata().GetName()),
+		Spec:       protoJSON(normalize(policy)),
+	}
+}
+
+func networkSLAManifestOf(sla *awi.NetworkSLA) resourceManifest {
+	manifest := resourceManifest{
+		APIVersion: manifestAPIVersion,
+		Kind:       networkSLAKind,
+		Metadata:   nameMetadata(sla.GetMetadata().GetName()),
+		NetworkSLA: protoJSON(normalize(sla)),
+	}
+	if sla.GetMetadata().GetCreationTimestamp() != "" || sla.GetMetadata().GetModificationTimestamp() != "" {
+		manifest.Status = &resourceStatus{
+			CreationTimestamp:     sla.GetMetadata().GetCreationTimestamp(),
+			ModificationTimestamp: sla.GetMetadata().GetModificationTimestamp(),
+		}
+	}
+	return manifest
+}
+
+// printObject prints a single object returned by a get command. JSON is
+// the default output. In YAML output the object is printed as its manifest
+// if it has one.
+func printObject(cmd *cobra.Command, m proto.Message, manifest *resourceManifest) error {
+	format := cmd.Flag(outputFlag).Value.String()
+	switch {
+	case format == "":
+		format = jsonOutput
+	case format == yamlOutput && manifest != nil:
+		prettyprint.PrintDocuments([]resourceManifest{*manifest})
+		return nil
+	}
+	return prettyprint.PrintObject(m, format)
+}
+
+// printResource prints an object returned by a get command like printObject
+// and, in the table, wide and delimited outputs of list commands, as a row
+// of the table listing it, fitted to the terminal and colored like the
+// tables of list commands.
+func printResource[T proto.Message, C any](cmd *cobra.Command, m T, converted C, displays []prettyprint.Display,
+	manifest resourceManifest) error {
+	if format := cmd.Flag(outputFlag).Value.String(); format != "" && prettyprint.PrintsRows(format) {
+		prettyprint.SetTableOptions(terminalTableOptions(prettyprint.TableOptions{}, format))
+		return prettyprint.PrintConvertedData([]T{m}, []C{converted}, displays, format)
+	}
+	return printObject(cmd, m, &manifest)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const dryRunFlag = "dry-run"
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what applying manifests would do",
+	Long: `Show what applying manifests would do without changing anything.
+
+For every manifest the action taken by apply is printed. The network domain,
+endpoint and subnet selectors of connections and app connections are
+resolved against the cloud inventory and the matching VPCs, VRFs, subnets
+and instances are listed.`,
+	Args: cobra.NoArgs,
+	RunE: plan,
+}
+
+// planner is implemented by resources whose selectors can be resolved
+// before the resource is created.
+type planner interface {
+	plan(ctx context.Context, r *selectorResolver, w io.Writer) error
+}
+
+func plan(cmd *cobra.Command, _ []string) error {
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(cmd.Flag(filenameFlag).Value.String(), recursive)
+	if err != nil {
+		return err
+	}
+
+	// Set up a connection to the server.
+	conn, err := clients.connection()
+	if err != nil {
+		return err
+	}
+
+	resolver := newSelectorResolver(conn)
+	return processDocuments(documents, func(doc document) error {
+		resource, err := newManifestResource(doc.Viper)
+		if err != nil {
+			return err
+		}
+		if err := planManifest(conn, resolver, resource, os.Stdout); err != nil {
+			return fmt.Errorf("could not plan %s %q: %v", resource.kind(), resource.name(), err)
+		}
+		return nil
+	})
+}
+
+// planManifest prints the action apply would take for the resource
+// followed by the resources matched by its selectors.
+func planManifest(conn *grpc.ClientConn, r *selectorResolver, m manifestResource, w io.Writer) error {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	id, live, err := m.find(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("could not look up existing %s: %v", m.kind(), err)
+	}
+	action := "would be created"
+	if id != "" {
+		if equalManifest(m, live) {
+			action = "unchanged"
+		} else {
+			action = fmt.Sprintf("differs from %s, would be replaced with --%s", id, forceFlag)
+		}
+	}
+	fmt.Fprintf(w, "%s/%s %s\n", m.kind(), m.name(), action)
+	return printPlan(ctx, r, m, w)
+}
+
+// dryRun prints the resources matched by the selectors of a resource that
+// would be created by a create subcommand.
+func dryRun(m manifestResource) error {
+	conn, err := clients.connection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	fmt.Printf("%s/%s would be created (dry run)\n", m.kind(), m.name())
+	return printPlan(ctx, newSelectorResolver(conn), m, os.Stdout)
+}
+
+func printPlan(ctx context.Context, r *selectorResolver, m manifestResource, w io.Writer) error {
+	p, ok := m.(planner)
+	if !ok {
+		return nil
+	}
+	return p.plan(ctx, r, w)
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.Flags().StringP(filenameFlag, "f", "", "Manifest file, directory of manifests or - for standard input")
+	_ = planCmd.MarkFlagRequired(filenameFlag)
+	planCmd.Flags().BoolP(recursiveFlag, "R", false, "Process the directory used in -f recursively")
+}
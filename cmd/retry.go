@@ -0,0 +1,176 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	timeoutFlag             = "timeout"
+	timeoutConfigFlag       = globalsFlag + ".timeout"
+	retryFlag               = globalsFlag + ".retry"
+	retryMaxAttemptsFlag    = retryFlag + ".max_attempts"
+	retryInitialBackoffFlag = retryFlag + ".initial_backoff"
+	retryMaxBackoffFlag     = retryFlag + ".max_backoff"
+
+	defaultTimeout             = 10 * time.Second
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// jitter returns a random duration in [0, d), replaced in tests.
+var jitter = func(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+// retryPolicy configures how idempotent calls are retried.
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// newRetryPolicy returns the retry policy in the config.
+func newRetryPolicy() retryPolicy {
+	p := retryPolicy{
+		maxAttempts:    viper.GetInt(retryMaxAttemptsFlag),
+		initialBackoff: viper.GetDuration(retryInitialBackoffFlag),
+		maxBackoff:     viper.GetDuration(retryMaxBackoffFlag),
+	}
+	if !viper.IsSet(retryMaxAttemptsFlag) {
+		p.maxAttempts = defaultRetryMaxAttempts
+	}
+	if p.maxAttempts < 1 {
+		p.maxAttempts = 1
+	}
+	if p.initialBackoff <= 0 {
+		p.initialBackoff = defaultRetryInitialBackoff
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = defaultRetryMaxBackoff
+	}
+	return p
+}
+
+// backoff returns the exponential backoff before the given retry, with
+// full jitter.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	d := p.initialBackoff
+	for i := 0; i < retry && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	return jitter(min(d, p.maxBackoff))
+}
+
+// requestTimeout returns the timeout of a single call, taken from --timeout
+// or the config.
+func requestTimeout() time.Duration {
+	if f := rootCmd.PersistentFlags().Lookup(timeoutFlag); f != nil && f.Changed {
+		if timeout, err := rootCmd.PersistentFlags().GetDuration(timeoutFlag); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+	if timeout := viper.GetDuration(timeoutConfigFlag); timeout > 0 {
+		return timeout
+	}
+	return defaultTimeout
+}
+
+// requestContext returns the context of the calls of a command. Its
+// deadline leaves room for every attempt of a retried call.
+func requestContext() (context.Context, context.CancelFunc) {
+	p := newRetryPolicy()
+	budget := time.Duration(p.maxAttempts)*requestTimeout() + time.Duration(p.maxAttempts-1)*p.maxBackoff
+	return context.WithTimeout(context.Background(), budget)
+}
+
+// timeoutInterceptor bounds every attempt of a call by the timeout.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// retryInterceptor retries idempotent calls, the List and Get methods,
+// which fail because the controller is unavailable or too slow.
+func retryInterceptor(p retryPolicy) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if !idempotentMethod(method) {
+			return err
+		}
+		for retry := 0; retry+1 < p.maxAttempts && retryableError(err); retry++ {
+			backoff := p.backoff(retry)
+			logger.Infof("%s failed: %v, retrying in %s", method, err, backoff)
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
+
+// idempotentMethod reports whether the gRPC method only reads resources.
+func idempotentMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	return strings.HasPrefix(name, "List") || strings.HasPrefix(name, "Get")
+}
+
+func retryableError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func init() {
+	rootCmd.PersistentFlags().Duration(timeoutFlag, 0, "Timeout of every call to the controller, overrides "+timeoutConfigFlag+" (default 10s)")
+}
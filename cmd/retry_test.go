@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryInterceptor(t *testing.T) {
+	p := retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}
+	tests := []struct {
+		name     string
+		method   string
+		errors   []codes.Code
+		attempts int
+		code     codes.Code
+	}{
+		{
+			name:     "list retried until it succeeds",
+			method:   "/grpc/awi.ConnectionController/ListConnections",
+			errors:   []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+			attempts: 3,
+			code:     codes.OK,
+		},
+		{
+			name:     "get gives up after max attempts",
+			method:   "/awi.AppConnectionController/GetAppConnection",
+			errors:   []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable},
+			attempts: 3,
+			code:     codes.Unavailable,
+		},
+		{
+			name:     "not found is not retried",
+			method:   "/awi.ConnectionController/GetConnection",
+			errors:   []codes.Code{codes.NotFound},
+			attempts: 1,
+			code:     codes.NotFound,
+		},
+		{
+			name:     "create is not retried",
+			method:   "/awi.ConnectionController/Connect",
+			errors:   []codes.Code{codes.Unavailable},
+			attempts: 1,
+			code:     codes.Unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			invoker := func(ctx context.Context, method string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				attempts++
+				if attempts <= len(tt.errors) {
+					return status.Error(tt.errors[attempts-1], "failed")
+				}
+				return nil
+			}
+			err := retryInterceptor(p)(context.Background(), tt.method, nil, nil, nil, invoker)
+			require.Equal(t, tt.code, status.Code(err))
+			require.Equal(t, tt.attempts, attempts)
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	random := jitter
+	t.Cleanup(func() {
+		jitter = random
+	})
+	jitter = func(d time.Duration) time.Duration { return d }
+	p := retryPolicy{maxAttempts: 5, initialBackoff: 500 * time.Millisecond, maxBackoff: 3 * time.Second}
+	var backoffs []time.Duration
+	for retry := 0; retry < 4; retry++ {
+		backoffs = append(backoffs, p.backoff(retry))
+	}
+	require.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second}, backoffs)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	t.Cleanup(func() {
+		resetConfig()
+		flag := rootCmd.PersistentFlags().Lookup(timeoutFlag)
+		_ = flag.Value.Set("0s")
+		flag.Changed = false
+	})
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`globals:
+  timeout: 20s
+current_context: busy
+contexts:
+  busy:
+    globals:
+      timeout: 45s
+      retry:
+        max_attempts: 5
+`), 0o600))
+	require.Equal(t, defaultTimeout, requestTimeout())
+	require.Equal(t, retryPolicy{
+		maxAttempts:    defaultRetryMaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+	}, newRetryPolicy())
+
+	require.NoError(t, initConfig(path))
+	require.Equal(t, 45*time.Second, requestTimeout())
+	require.Equal(t, 5, newRetryPolicy().maxAttempts)
+
+	require.NoError(t, rootCmd.PersistentFlags().Set(timeoutFlag, "1m"))
+	require.Equal(t, time.Minute, requestTimeout())
+}
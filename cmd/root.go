@@ -36,7 +36,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
@@ -67,25 +67,19 @@ var logger = log.New()
 
 func Execute() {
 	err := rootCmd.Execute()
+	clients.close()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
 func init() {
+	rootCmd.PersistentPreRunE = setUpClients
 	rootCmd.PersistentFlags().StringP(configFlag, "c", "config.yaml", "Configuration file in YAML format")
 }
 
 func getClientWithJar(jar *cookiejar.Jar) (vmanage.Client, error) {
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: !viper.GetBool(secureConnectionFlag),
-		MinVersion:         tls.VersionTLS12,
-	}
-	httpclient := &http.Client{
-		Transport: transport,
-		Jar:       jar,
-	}
+	httpclient := vManageHTTPClient(jar)
 	controllerURL := viper.GetString(urlFlag)
 	retries := viper.GetInt(longPollRetriesFlag)
 	retriesInterval := viper.GetDuration(retriesIntervalFlag)
@@ -96,6 +90,19 @@ func getClientWithJar(jar *cookiejar.Jar) (vmanage.Client, error) {
 	return client, nil
 }
 
+// vManageHTTPClient returns the HTTP client used to reach vManage.
+func vManageHTTPClient(jar *cookiejar.Jar) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: !viper.GetBool(secureConnectionFlag),
+		MinVersion:         tls.VersionTLS12,
+	}
+	return &http.Client{
+		Transport: transport,
+		Jar:       jar,
+	}
+}
+
 // apiPrefixInterceptor attaches the API prefix for the Envoy Proxy
 // to distinguish that calls performed by the AWI-CLI should be forwarded
 // to backend services rather than front-end.
@@ -120,23 +127,44 @@ func apiPrefixInterceptor(prefix string) grpc.UnaryClientInterceptor {
 	}
 }
 
-func getGRPCClient() (*grpc.ClientConn, error) {
+// keepaliveTime is the interval of keepalive pings during calls. It matches
+// the minimum interval gRPC servers accept by default.
+const keepaliveTime = 5 * time.Minute
+
+// getGRPCClient connects to the controller in the config. The options are
+// added to the ones derived from the config.
+func getGRPCClient(options ...grpc.DialOption) (*grpc.ClientConn, error) {
 	address := viper.GetString(urlFlag)
 	proxyEnabled := viper.GetBool(useProxyFlag)
 	logger.Debugf("connecting to %s", address)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	timeout := requestTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	transportCredentials, err := grpcTransportCredentials()
+	if err != nil {
+		return nil, err
+	}
 	dialOptions := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
 		grpc.WithBlock(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: timeout,
+		}),
 	}
+	var interceptors []grpc.UnaryClientInterceptor
 	if proxyEnabled {
-		dialOptions = append(
-			dialOptions,
-			grpc.WithUnaryInterceptor(apiPrefixInterceptor("/grpc")),
-		)
+		interceptors = append(interceptors, apiPrefixInterceptor("/grpc"))
 	}
+	interceptors = append(interceptors, retryInterceptor(newRetryPolicy()))
+	if callCredentials := newControllerCredentials(); callCredentials != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(callCredentials))
+		interceptors = append(interceptors, reloginInterceptor(callCredentials))
+	}
+	interceptors = append(interceptors, timeoutInterceptor(timeout))
+	dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(interceptors...))
+	dialOptions = append(dialOptions, options...)
 
 	conn, err := grpc.DialContext(
 		ctx,
@@ -165,6 +193,12 @@ func initConfig(configFilePath string) error {
 		}
 		return err
 	}
+	if err := applyContext(); err != nil {
+		return err
+	}
+	if err := loadCredentials(); err != nil {
+		return err
+	}
 
 	if err := initLogger(); err != nil {
 		return err
@@ -175,37 +209,38 @@ func initConfig(configFilePath string) error {
 	return nil
 }
 
-func getConnectionConfigGRPC(configFilePath string) (*awi.ConnectionRequest, error) {
-	if err := loadConfig(configFilePath); err != nil {
+func getAppConnectionConfigGRPC(configFilePath string) (*awi.AppConnection, error) {
+	doc, err := readDocument(configFilePath)
+	if err != nil {
 		return nil, err
 	}
-	logger.Infof("Using connection config file: %s", viper.ConfigFileUsed())
+	logger.Infof("Using connection config file: %s", configFilePath)
+	return decodeAppConnectionConfig(doc.Viper)
+}
+
+func decodeConnectionConfig(v *viper.Viper) (*awi.ConnectionRequest, error) {
 	request := &awi.ConnectionRequest{}
 
-	if err := viper.UnmarshalKey(specFlag, &request.Spec,
+	if err := v.UnmarshalKey(specFlag, &request.Spec,
 		func(config *mapstructure.DecoderConfig) { config.ErrorUnused = true }); err != nil {
 		return nil, fmt.Errorf("could not read connection spec: %v", err)
 	}
-	if err := viper.UnmarshalKey(metadataFlag, &request.Metadata); err != nil {
+	if err := v.UnmarshalKey(metadataFlag, &request.Metadata); err != nil {
 		return nil, fmt.Errorf("could not read connection metadata: %v", err)
 	}
 	return request, nil
 }
 
-func getAppConnectionConfigGRPC(configFilePath string) (*awi.AppConnection, error) {
-	if err := loadConfig(configFilePath); err != nil {
-		return nil, err
-	}
-	logger.Infof("Using connection config file: %s", viper.ConfigFileUsed())
+func decodeAppConnectionConfig(v *viper.Viper) (*awi.AppConnection, error) {
 	var acl *awi.AppConnection
-	err := viper.UnmarshalKey(accessRequestFlag, &acl, func(config *mapstructure.DecoderConfig) {
+	err := v.UnmarshalKey(accessRequestFlag, &acl, func(config *mapstructure.DecoderConfig) {
 		config.ErrorUnused = true
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not read app connection config: %v", err)
 	}
 	if acl == nil {
-		err := viper.UnmarshalKey(fmt.Sprintf(specFlag+"."+accessRequestFlag), &acl, func(config *mapstructure.DecoderConfig) {
+		err := v.UnmarshalKey(fmt.Sprintf(specFlag+"."+accessRequestFlag), &acl, func(config *mapstructure.DecoderConfig) {
 			config.ErrorUnused = true
 		})
 		if err != nil {
@@ -218,25 +253,17 @@ func getAppConnectionConfigGRPC(configFilePath string) (*awi.AppConnection, erro
 	return acl, nil
 }
 
-func getNetworkSLAConfigGRPC(configFilePath string) (*awi.NetworkSLA, error) {
-	if err := loadConfig(configFilePath); err != nil {
-		return nil, err
-	}
-	logger.Infof("Using networkSLA config file: %s", viper.ConfigFileUsed())
+func decodeNetworkSLAConfig(v *viper.Viper) (*awi.NetworkSLA, error) {
 	var request *awi.NetworkSLA
-	if err := viper.UnmarshalKey(networkSLAFlag, &request); err != nil {
+	if err := v.UnmarshalKey(networkSLAFlag, &request); err != nil {
 		return nil, fmt.Errorf("could not read networkSLA config: %v", err)
 	}
 	return request, nil
 }
 
-func getAccessControlConfigGRPC(configFilePath string) (*awi.Security_AccessPolicy, error) {
-	if err := loadConfig(configFilePath); err != nil {
-		return nil, err
-	}
-	logger.Infof("Using Access Policy config file: %s", viper.ConfigFileUsed())
+func decodeAccessControlConfig(v *viper.Viper) (*awi.Security_AccessPolicy, error) {
 	var request *awi.Security_AccessPolicy
-	if err := viper.UnmarshalKey(specFlag, &request, func(config *mapstructure.DecoderConfig) {
+	if err := v.UnmarshalKey(specFlag, &request, func(config *mapstructure.DecoderConfig) {
 		config.ErrorUnused = true
 	}); err != nil {
 		return nil, fmt.Errorf("could not read access policy config: %v", err)
@@ -244,14 +271,6 @@ func getAccessControlConfigGRPC(configFilePath string) (*awi.Security_AccessPoli
 	return request, nil
 }
 
-func loadConfig(configFilePath string) error {
-	viper.SetConfigFile(configFilePath)
-	if err := viper.MergeInConfig(); err != nil {
-		return err
-	}
-	return nil
-}
-
 func initLogger() error {
 	logLevel := viper.GetString(logLevelFlag)
 	logger = log.New()
@@ -0,0 +1,468 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	selectorField   = "selector"
+	networkPathFlag = "networkPath"
+	minPort         = 1
+	maxPort         = 65535
+)
+
+// knownProtocols lists protocols accepted in access policies and
+// app connection access controls.
+var knownProtocols = []string{"TCP", "UDP", "ICMP", "HTTP", "HTTPS", "ANY"}
+
+// schemaNode describes a key allowed in a manifest. Envelope keys such as
+// spec hold nested fields, while keys mapped to protobuf messages are
+// validated against the message descriptor. Ignored keys may hold any
+// value.
+type schemaNode struct {
+	message protoreflect.MessageDescriptor
+	fields  map[string]*schemaNode
+	ignored bool
+}
+
+func messageSchema(m interface{ ProtoReflect() protoreflect.Message }) *schemaNode {
+	return &schemaNode{message: awiDescriptor(m)}
+}
+
+// envelopeSchema returns top level keys allowed in a manifest of the given
+// kind or nil if the kind is not supported.
+func envelopeSchema(kind string) *schemaNode {
+	fields := map[string]*schemaNode{
+		"apiVersion": {},
+		kindFlag:     {},
+		metadataFlag: messageSchema(&awi.ConnectionMetadata{}),
+		// status is printed by list and get commands in YAML output.
+		statusField: {ignored: true},
+	}
+	switch strings.ToLower(kind) {
+	case strings.ToLower(connectionKind):
+		fields[specFlag] = messageSchema(&awi.NetworkDomainConnectionConfig{})
+	case strings.ToLower(appConnectionKind):
+		fields[accessRequestFlag] = messageSchema(&awi.AppConnection{})
+		fields[specFlag] = &schemaNode{fields: map[string]*schemaNode{
+			accessRequestFlag: messageSchema(&awi.AppConnection{}),
+		}}
+	case strings.ToLower(accessPolicyKind):
+		fields[specFlag] = messageSchema(&awi.Security_AccessPolicy{})
+	case strings.ToLower(networkSLAKind):
+		fields[networkSLAFlag] = messageSchema(&awi.NetworkSLA{})
+		fields[networkPathFlag] = messageSchema(&awi.NetworkPath{})
+	default:
+		return nil
+	}
+	return &schemaNode{fields: fields}
+}
+
+// requiredFields returns paths that must be set in a manifest of the given
+// kind. Every entry lists alternative locations of the same field and is
+// satisfied if any of them is set.
+func requiredFields(kind string) [][]string {
+	switch strings.ToLower(kind) {
+	case strings.ToLower(connectionKind):
+		return [][]string{
+			{"metadata.name"},
+			{"spec.source.networkDomain"},
+			{"spec.destination.networkDomain"},
+		}
+	case strings.ToLower(appConnectionKind):
+		return [][]string{
+			{"appConnection.metadata.name", "spec.appConnection.metadata.name"},
+			{"appConnection.from", "spec.appConnection.from"},
+			{"appConnection.to", "spec.appConnection.to"},
+		}
+	case strings.ToLower(accessPolicyKind):
+		return [][]string{{"spec.metadata.name"}}
+	case strings.ToLower(networkSLAKind):
+		return [][]string{{"networkSLA.metadata.name"}}
+	}
+	return nil
+}
+
+// schemaError is a single problem found in a manifest.
+type schemaError struct {
+	line    int
+	column  int
+	path    string
+	message string
+}
+
+func (e schemaError) format(file string) string {
+	if e.path == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", file, e.line, e.column, e.message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", file, e.line, e.column, e.path, e.message)
+}
+
+// validator checks a YAML document against the protobuf messages it is
+// decoded into, following the same rules as the decoder: keys are matched
+// case insensitively and scalars are converted between types when possible.
+type validator struct {
+	errors []schemaError
+}
+
+// validateDocument returns all problems found in the document. It does not
+// contact the controller.
+func validateDocument(doc document) []schemaError {
+	v := &validator{}
+	kind := manifestKind(doc.Viper)
+	schema := envelopeSchema(kind)
+	if schema == nil {
+		node := doc.node
+		if kindNode, _ := lookupNode(doc.node, []string{kindFlag}); kindNode != nil {
+			node = kindNode
+		}
+		if kind == "" {
+			v.errorf(node, "", "missing kind")
+		} else {
+			v.errorf(node, kindFlag, "unsupported kind %q", kind)
+		}
+		return v.errors
+	}
+	v.envelope(doc.node, schema, "")
+	for _, alternatives := range requiredFields(kind) {
+		v.required(doc.node, alternatives)
+	}
+	if len(v.errors) == 0 {
+		if _, err := newManifestResource(doc.Viper); err != nil {
+			v.errorf(doc.node, "", "%v", err)
+		}
+	}
+	return v.errors
+}
+
+func (v *validator) errorf(node *yaml.Node, path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, schemaError{
+		line:    node.Line,
+		column:  node.Column,
+		path:    path,
+		message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) envelope(node *yaml.Node, schema *schemaNode, path string) {
+	node = resolveAlias(node)
+	if schema.ignored {
+		return
+	}
+	if schema.message != nil {
+		v.message(node, schema.message, path)
+		return
+	}
+	if schema.fields == nil {
+		if isNull(node) || node.Kind != yaml.ScalarNode {
+			v.errorf(node, path, "expected a string, got %s", describeNode(node))
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, path, "expected an object, got %s", describeNode(node))
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fieldPath := joinPath(path, key.Value)
+		field := lookupSchema(schema.fields, key.Value)
+		if field == nil {
+			v.errorf(key, fieldPath, "unknown field")
+			continue
+		}
+		v.envelope(value, field, fieldPath)
+	}
+}
+
+func (v *validator) message(node *yaml.Node, md protoreflect.MessageDescriptor, path string) {
+	node = resolveAlias(node)
+	if isNull(node) {
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, path, "expected %s object, got %s", md.Name(), describeNode(node))
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fieldPath := joinPath(path, key.Value)
+		fd := lookupField(md, key.Value)
+		if fd == nil {
+			v.errorf(key, fieldPath, "unknown field in %s", md.Name())
+			continue
+		}
+		v.field(value, fd, fieldPath)
+	}
+	if fd := md.Fields().ByName(selectorField); fd != nil && fd.Message() != nil {
+		selector, _ := lookupNode(node, []string{selectorField})
+		switch {
+		case selector == nil || isNull(selector):
+			v.errorf(node, joinPath(path, selectorField), "missing required selector")
+		case selector.Kind == yaml.MappingNode && len(selector.Content) == 0:
+			v.errorf(selector, joinPath(path, selectorField), "selector must define at least one match criteria")
+		}
+	}
+	if md.FullName() == awiDescriptor(&awi.NetworkAccessControl{}).FullName() ||
+		md.FullName() == awiDescriptor(&awi.Security_AccessPolicy_AccessProtocol{}).FullName() {
+		v.accessProtocol(node, path)
+	}
+}
+
+func (v *validator) field(node *yaml.Node, fd protoreflect.FieldDescriptor, path string) {
+	node = resolveAlias(node)
+	if isNull(node) {
+		return
+	}
+	switch {
+	case fd.IsMap():
+		if node.Kind != yaml.MappingNode {
+			v.errorf(node, path, "expected a map, got %s", describeNode(node))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			v.value(value, fd.MapValue(), joinPath(path, key.Value))
+		}
+	case fd.IsList():
+		if node.Kind != yaml.SequenceNode {
+			// The decoder converts single values to lists.
+			v.value(node, fd, path)
+			return
+		}
+		for i, item := range node.Content {
+			v.value(item, fd, fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		v.value(node, fd, path)
+	}
+}
+
+func (v *validator) value(node *yaml.Node, fd protoreflect.FieldDescriptor, path string) {
+	node = resolveAlias(node)
+	if isNull(node) {
+		return
+	}
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		v.message(node, fd.Message(), path)
+		return
+	}
+	if node.Kind != yaml.ScalarNode {
+		v.errorf(node, path, "expected %s, got %s", describeKind(fd.Kind()), describeNode(node))
+		return
+	}
+	var err error
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if node.Tag != "!!bool" && node.Tag != "!!int" {
+			_, err = strconv.ParseBool(node.Value)
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if node.Tag != "!!int" && node.Tag != "!!float" {
+			_, err = strconv.ParseFloat(node.Value, 64)
+		}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+	default:
+		// Integers and enums.
+		if node.Tag != "!!int" && node.Tag != "!!bool" {
+			_, err = strconv.ParseInt(node.Value, 0, 64)
+		}
+	}
+	if err != nil {
+		v.errorf(node, path, "expected %s, got %q", describeKind(fd.Kind()), node.Value)
+	}
+}
+
+// accessProtocol validates protocol and port of access control entries.
+func (v *validator) accessProtocol(node *yaml.Node, path string) {
+	protocol, _ := lookupNode(node, []string{"protocol"})
+	port, _ := lookupNode(node, []string{"port"})
+	if protocol == nil || isNull(protocol) {
+		v.errorf(node, joinPath(path, "protocol"), "missing protocol")
+	} else if !isKnownProtocol(protocol.Value) {
+		v.errorf(protocol, joinPath(path, "protocol"), "unknown protocol %q, expected one of %s",
+			protocol.Value, strings.Join(knownProtocols, ", "))
+	}
+	if port == nil || isNull(port) || port.Kind != yaml.ScalarNode {
+		return
+	}
+	if protocol != nil && strings.EqualFold(protocol.Value, "ICMP") {
+		v.errorf(port, joinPath(path, "port"), "port cannot be specified for protocol ICMP")
+		return
+	}
+	if err := validatePortRange(port.Value); err != nil {
+		v.errorf(port, joinPath(path, "port"), "%v", err)
+	}
+}
+
+// required reports a missing field if none of the alternative paths
+// exists. The error points at the closest existing parent.
+func (v *validator) required(root *yaml.Node, alternatives []string) {
+	parent, path := root, alternatives[0]
+	deepest := 0
+	for _, alternative := range alternatives {
+		elements := strings.Split(alternative, ".")
+		node, depth := lookupNode(root, elements)
+		if !isNull(node) {
+			return
+		}
+		if depth > deepest {
+			parent, _ = lookupNode(root, elements[:depth])
+			path, deepest = alternative, depth
+		}
+	}
+	v.errorf(parent, path, "missing required field")
+}
+
+// validatePortRange accepts a single port or a range in the form from-to.
+func validatePortRange(value string) error {
+	bounds := strings.Split(value, "-")
+	if len(bounds) > 2 {
+		return fmt.Errorf("malformed port range %q, expected port or from-to", value)
+	}
+	ports := make([]int, 0, len(bounds))
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(strings.TrimSpace(bound))
+		if err != nil {
+			return fmt.Errorf("malformed port range %q, expected port or from-to", value)
+		}
+		if port < minPort || port > maxPort {
+			return fmt.Errorf("port %d out of range %d-%d", port, minPort, maxPort)
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return fmt.Errorf("malformed port range %q, start is greater than end", value)
+	}
+	return nil
+}
+
+func isKnownProtocol(protocol string) bool {
+	for _, known := range knownProtocols {
+		if strings.EqualFold(protocol, known) {
+			return true
+		}
+	}
+	return false
+}
+
+func awiDescriptor(m interface{ ProtoReflect() protoreflect.Message }) protoreflect.MessageDescriptor {
+	return m.ProtoReflect().Descriptor()
+}
+
+// lookupField matches keys the same way the decoder does: case
+// insensitively against the Go field name, which drops underscores of the
+// protobuf name.
+func lookupField(md protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(key, strings.ReplaceAll(string(fd.Name()), "_", "")) ||
+			strings.EqualFold(key, fd.JSONName()) {
+			return fd
+		}
+	}
+	return nil
+}
+
+func lookupSchema(fields map[string]*schemaNode, key string) *schemaNode {
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field
+		}
+	}
+	return nil
+}
+
+// lookupNode returns the node under the given path and the number of path
+// elements that were found.
+func lookupNode(node *yaml.Node, path []string) (*yaml.Node, int) {
+	for depth, element := range path {
+		node = resolveAlias(node)
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, depth
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, element) {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil, depth
+		}
+		node = next
+	}
+	return resolveAlias(node), len(path)
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func isNull(node *yaml.Node) bool {
+	return node == nil || (node.Kind == yaml.ScalarNode && node.Tag == "!!null")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.ScalarNode:
+		if isNull(node) {
+			return "null"
+		}
+		return fmt.Sprintf("%q", node.Value)
+	}
+	return "an unsupported value"
+}
+
+func describeKind(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.BoolKind:
+		return "a boolean"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "a number"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "a string"
+	case protoreflect.EnumKind:
+		return "an enum number"
+	}
+	return "an integer"
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "valid connection",
+			manifest: `kind: InterNetworkDomainConnection
+metadata:
+  name: vpc-to-vpc
+spec:
+  source:
+    networkDomain:
+      selector:
+        matchId:
+          id: vpc-1
+  destination:
+    networkDomain:
+      selector:
+        matchLabels:
+          env: sandbox
+`,
+		},
+		{
+			name: "unknown field and missing selector",
+			manifest: `kind: InterNetworkDomainConnection
+metadata:
+  name: vpc-to-vpc
+spec:
+  source:
+    networkDomain:
+      selector:
+        matchID:
+          identifier: vpc-1
+  destination:
+    networkDomain:
+      accountID: "123"
+`,
+			expected: []string{
+				"test:9:11: spec.source.networkDomain.selector.matchID.identifier: unknown field in MatchId",
+				"test:12:7: spec.destination.networkDomain.selector: missing required selector",
+			},
+		},
+		{
+			name: "access protocols",
+			manifest: `kind: accessPolicy
+spec:
+  metadata:
+    name: policy
+  priority: high
+  accessProtocols:
+    - protocol: TCP
+      port: 3306-3310
+    - protocol: tcp
+      port: 8000
+    - protocol: TCP
+      port: 3306-
+    - protocol: SCTP
+`,
+			expected: []string{
+				`test:5:13: spec.priority: expected an integer, got "high"`,
+				`test:12:13: spec.accessProtocols[2].port: malformed port range "3306-", expected port or from-to`,
+				`test:13:17: spec.accessProtocols[3].protocol: unknown protocol "SCTP", expected one of TCP, UDP, ICMP, HTTP, HTTPS, ANY`,
+			},
+		},
+		{
+			name: "missing required fields",
+			manifest: `apiVersion: awi.app-net-interface.io/v1alpha1
+kind: InterNetworkDomainAppConnection
+state: ready
+spec:
+  appConnection:
+    from:
+      endpoint:
+        selector:
+          matchLabels:
+            env: staging
+`,
+			expected: []string{
+				"test:3:1: state: unknown field",
+				"test:6:5: spec.appConnection.metadata.name: missing required field",
+				"test:6:5: spec.appConnection.to: missing required field",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := decodeDocuments(strings.NewReader(tt.manifest), "test")
+			require.NoError(t, err)
+			require.Len(t, documents, 1)
+			var problems []string
+			for _, problem := range validateDocument(documents[0]) {
+				problems = append(problems, problem.format("test"))
+			}
+			require.Equal(t, tt.expected, problems)
+		})
+	}
+}
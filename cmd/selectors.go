@@ -0,0 +1,459 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+	"google.golang.org/grpc"
+)
+
+// cloudProviders are the providers queried for VPCs, subnets and instances.
+var cloudProviders = []string{"aws", "gcp"}
+
+// domainSelector holds the criteria used to select network domains. All
+// criteria which are set have to match.
+type domainSelector struct {
+	// kind limits the selector to VPCs or VRFs, empty matches both.
+	kind   string
+	id     string
+	name   string
+	labels map[string]string
+}
+
+func (s domainSelector) empty() bool {
+	return s.id == "" && s.name == "" && len(s.labels) == 0
+}
+
+func (s domainSelector) String() string {
+	var parts []string
+	if s.kind != "" {
+		parts = append(parts, "kind "+s.kind)
+	}
+	if s.id != "" {
+		parts = append(parts, "matchId "+s.id)
+	}
+	if s.name != "" {
+		parts = append(parts, "matchName "+s.name)
+	}
+	if len(s.labels) > 0 {
+		parts = append(parts, "matchLabels "+formatLabels(s.labels))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s domainSelector) matches(d networkDomain, labels map[string]string) bool {
+	if s.empty() || (s.kind != "" && !strings.EqualFold(s.kind, d.Type)) {
+		return false
+	}
+	return (s.id == "" || s.id == d.ID) && (s.name == "" || s.name == d.Name) && matchLabels(s.labels, labels)
+}
+
+// selectorResolver resolves selectors against the cloud inventory. VPCs and
+// VRFs are listed once and reused for all selectors.
+type selectorResolver struct {
+	cloud       awi.CloudClient
+	infra       infrapb.CloudProviderServiceClient
+	connections awi.ConnectionControllerClient
+
+	listed bool
+	vpcs   []*infrapb.VPC
+	vpns   []*awi.VPN
+}
+
+func newSelectorResolver(conn *grpc.ClientConn) *selectorResolver {
+	return &selectorResolver{
+		cloud:       awi.NewCloudClient(conn),
+		infra:       infrapb.NewCloudProviderServiceClient(conn),
+		connections: awi.NewConnectionControllerClient(conn),
+	}
+}
+
+func (r *selectorResolver) listNetworkDomains(ctx context.Context) error {
+	if r.listed {
+		return nil
+	}
+	vpns, err := r.cloud.ListVPNs(ctx, &awi.ListVPNRequest{})
+	if err != nil {
+		return fmt.Errorf("could not list VRFs: %v", err)
+	}
+	r.vpns = vpns.GetVPNs()
+	for _, provider := range cloudProviders {
+		vpcs, err := r.infra.ListVPC(ctx, &infrapb.ListVPCRequest{Provider: provider})
+		if err != nil {
+			return fmt.Errorf("could not list %s VPCs: %v", provider, err)
+		}
+		r.vpcs = append(r.vpcs, vpcs.GetVpcs()...)
+	}
+	r.listed = true
+	return nil
+}
+
+// networkDomains returns the VPCs and VRFs matched by the selector.
+func (r *selectorResolver) networkDomains(ctx context.Context, s domainSelector) ([]networkDomain, error) {
+	if err := r.listNetworkDomains(ctx); err != nil {
+		return nil, err
+	}
+	var domains []networkDomain
+	for _, vpn := range r.vpns {
+		domain := networkDomain{
+			Type:     "VRF",
+			Provider: "Cisco-SDWAN-vManage",
+			ID:       vpn.GetSegmentID(),
+			Name:     vpn.GetSegmentName(),
+		}
+		if s.matches(domain, nil) {
+			domains = append(domains, domain)
+		}
+	}
+	for _, vpc := range r.vpcs {
+		domain := networkDomain{
+			Type:     "VPC",
+			Provider: vpc.GetProvider(),
+			ID:       vpc.GetId(),
+			Name:     vpc.GetName(),
+		}
+		if s.matches(domain, vpc.GetLabels()) {
+			domains = append(domains, domain)
+		}
+	}
+	return domains, nil
+}
+
+// subnets returns the subnets of all providers which carry the labels and
+// fall into one of the prefixes. Empty labels and prefixes match all.
+func (r *selectorResolver) subnets(ctx context.Context, vpcID string, labels map[string]string, prefixes []string) ([]*infrapb.Subnet, error) {
+	var parsed []netip.Prefix
+	for _, prefix := range prefixes {
+		p, err := netip.ParsePrefix(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix %q: %v", prefix, err)
+		}
+		parsed = append(parsed, p)
+	}
+	var subnets []*infrapb.Subnet
+	for _, provider := range r.providers(vpcID) {
+		response, err := r.infra.ListSubnets(ctx, &infrapb.ListSubnetsRequest{
+			Provider: provider,
+			VpcId:    vpcID,
+			Labels:   labels,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s subnets: %v", provider, err)
+		}
+		for _, subnet := range response.GetSubnets() {
+			if matchLabels(labels, subnet.GetLabels()) && inPrefixes(subnet.GetCidrBlock(), parsed) {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+	return subnets, nil
+}
+
+// instances returns the instances of all providers which carry the labels
+// and, if name is set, have that name.
+func (r *selectorResolver) instances(ctx context.Context, vpcID string, labels map[string]string, name string) ([]*infrapb.Instance, error) {
+	var instances []*infrapb.Instance
+	for _, provider := range r.providers(vpcID) {
+		response, err := r.infra.ListInstances(ctx, &infrapb.ListInstancesRequest{
+			Provider: provider,
+			VpcId:    vpcID,
+			Labels:   labels,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s instances: %v", provider, err)
+		}
+		for _, instance := range response.GetInstances() {
+			if matchLabels(labels, instance.GetLabels()) && (name == "" || instance.GetName() == name) {
+				instances = append(instances, instance)
+			}
+		}
+	}
+	return instances, nil
+}
+
+// providers returns the providers to query for resources of the VPC. When
+// the VPC is known only its provider is queried.
+func (r *selectorResolver) providers(vpcID string) []string {
+	for _, vpc := range r.vpcs {
+		if vpcID != "" && vpc.GetId() == vpcID && vpc.GetProvider() != "" {
+			return []string{strings.ToLower(vpc.GetProvider())}
+		}
+	}
+	return cloudProviders
+}
+
+// printNetworkDomains prints the network domains matched by the selector
+// together with the subnets and instances of every matched VPC.
+func (r *selectorResolver) printNetworkDomains(ctx context.Context, w io.Writer, indent string, s domainSelector) error {
+	if s.empty() {
+		fmt.Fprintf(w, "%sno selector\n", indent)
+		return nil
+	}
+	domains, err := r.networkDomains(ctx, s)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		fmt.Fprintf(w, "%sno network domains match %s\n", indent, s)
+		return nil
+	}
+	for _, domain := range domains {
+		fmt.Fprintf(w, "%s%s %s %s (%s)\n", indent, domain.Type, domain.Provider, domain.ID, domain.Name)
+		if domain.Type != "VPC" {
+			continue
+		}
+		subnets, err := r.subnets(ctx, domain.ID, nil, nil)
+		if err != nil {
+			return err
+		}
+		printSubnets(w, indent+"  ", subnets)
+		instances, err := r.instances(ctx, domain.ID, nil, "")
+		if err != nil {
+			return err
+		}
+		printInstances(w, indent+"  ", instances)
+	}
+	return nil
+}
+
+func printSubnets(w io.Writer, indent string, subnets []*infrapb.Subnet) {
+	if len(subnets) == 0 {
+		fmt.Fprintf(w, "%sno subnets\n", indent)
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "%sSUBNET\tNAME\tCIDR\tVPC\tZONE\n", indent)
+	for _, subnet := range subnets {
+		fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", indent,
+			subnet.GetSubnetId(), subnet.GetName(), subnet.GetCidrBlock(), subnet.GetVpcId(), subnet.GetZone())
+	}
+	_ = tw.Flush()
+}
+
+func printInstances(w io.Writer, indent string, instances []*infrapb.Instance) {
+	if len(instances) == 0 {
+		fmt.Fprintf(w, "%sno instances\n", indent)
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "%sINSTANCE\tNAME\tPRIVATE IP\tSUBNET\tVPC\n", indent)
+	for _, instance := range instances {
+		fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n", indent,
+			instance.GetId(), instance.GetName(), instance.GetPrivateIP(), instance.GetSubnetID(), instance.GetVpcId())
+	}
+	_ = tw.Flush()
+}
+
+// matchLabels reports whether all selector labels are present in labels.
+func matchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// inPrefixes reports whether the CIDR lies within one of the prefixes.
+// Empty prefixes match every CIDR.
+func inPrefixes(cidr string, prefixes []netip.Prefix) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range prefixes {
+		if prefix.Bits() <= p.Bits() && prefix.Contains(p.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func connectionDomainSelector(domain *awi.NetworkDomainConnectionConfig_NetworkDomain) domainSelector {
+	selector := domain.GetSelector()
+	return domainSelector{
+		id:     selector.GetMatchId().GetId(),
+		name:   selector.GetMatchName().GetName(),
+		labels: selector.GetMatchLabels(),
+	}
+}
+
+func appDomainSelector(domain *awi.NetworkDomain) domainSelector {
+	selector := domain.GetSelector()
+	return domainSelector{
+		kind:   domain.GetKind(),
+		id:     selector.GetMatchID().GetId(),
+		name:   selector.GetMatchName().GetName(),
+		labels: selector.GetMatchLabels(),
+	}
+}
+
+func (m *connectionManifest) plan(ctx context.Context, r *selectorResolver, w io.Writer) error {
+	spec := m.request.GetSpec()
+	sides := []struct {
+		name   string
+		domain *awi.NetworkDomainConnectionConfig_NetworkDomain
+	}{
+		{"source", spec.GetSource().GetNetworkDomain()},
+		{"destination", spec.GetDestination().GetNetworkDomain()},
+	}
+	for _, side := range sides {
+		fmt.Fprintf(w, "  %s:\n", side.name)
+		if site := side.domain.GetSelector().GetMatchSite(); site != nil {
+			fmt.Fprintf(w, "    matchSite %s is resolved by the controller\n", site.GetId())
+			continue
+		}
+		if err := r.printNetworkDomains(ctx, w, "    ", connectionDomainSelector(side.domain)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *appConnectionManifest) plan(ctx context.Context, r *selectorResolver, w io.Writer) error {
+	if name := m.appConnection.GetNetworkDomainConnection().GetSelector().GetMatchName(); name != "" {
+		if err := r.printConnection(ctx, w, name); err != nil {
+			return err
+		}
+	}
+	from := m.appConnection.GetFrom()
+	fmt.Fprintf(w, "  from:\n")
+	if err := r.printAppEndpoints(ctx, w, from.GetEndpoint(), from.GetSubnet(), from.GetNetworkDomain()); err != nil {
+		return err
+	}
+	printUnresolved(w, "    ", map[string]bool{
+		"namespace": from.GetNamespace() != nil,
+		"SGT":       from.GetSGT() != nil,
+		"cluster":   from.GetCluster() != nil,
+	})
+	to := m.appConnection.GetTo()
+	fmt.Fprintf(w, "  to:\n")
+	if err := r.printAppEndpoints(ctx, w, to.GetEndpoint(), to.GetSubnet(), to.GetNetworkDomain()); err != nil {
+		return err
+	}
+	if len(to.GetExternalEntities()) > 0 {
+		fmt.Fprintf(w, "    external entities: %s\n", strings.Join(to.GetExternalEntities(), ", "))
+	}
+	printUnresolved(w, "    ", map[string]bool{
+		"namespace": to.GetNamespace() != nil,
+		"service":   to.GetService() != nil,
+		"cluster":   to.GetCluster() != nil,
+	})
+	return nil
+}
+
+// printConnection prints the network domain connection an app connection
+// is created for.
+func (r *selectorResolver) printConnection(ctx context.Context, w io.Writer, name string) error {
+	connections, err := r.connections.ListConnections(ctx, &awi.ListConnectionsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not list connections: %v", err)
+	}
+	for _, connection := range connections.GetConnections() {
+		if connection.GetMetadata().GetName() == name {
+			fmt.Fprintf(w, "  network domain connection: %s %s (status: %s)\n",
+				connection.GetId(), name, connection.GetStatus().String())
+			return nil
+		}
+	}
+	fmt.Fprintf(w, "  network domain connection: no connection named %s\n", name)
+	return nil
+}
+
+// printAppEndpoints prints the instances, subnets and network domains
+// selected by one side of an app connection.
+func (r *selectorResolver) printAppEndpoints(ctx context.Context, w io.Writer, endpoint *awi.Endpoint, subnet *awi.AppSubnet, domain *awi.NetworkDomain) error {
+	if endpoint != nil {
+		selector := endpoint.GetSelector()
+		fmt.Fprintf(w, "    endpoint:\n")
+		if len(selector.GetMatchLabels()) == 0 && selector.GetMatchName() == nil {
+			fmt.Fprintf(w, "      no labels or name to resolve\n")
+		} else {
+			instances, err := r.instances(ctx, "", selector.GetMatchLabels(), selector.GetMatchName().GetName())
+			if err != nil {
+				return err
+			}
+			printInstances(w, "      ", instances)
+		}
+		printUnresolved(w, "      ", map[string]bool{
+			"matchExpressions": len(selector.GetMatchExpressions()) > 0,
+			"matchCluster":     selector.GetMatchCluster() != nil,
+			"matchNamespace":   selector.GetMatchNamespace() != nil,
+		})
+	}
+	if subnet != nil {
+		selector := subnet.GetSelector()
+		fmt.Fprintf(w, "    subnet:\n")
+		if len(selector.GetMatchLabels()) == 0 && len(selector.GetMatchPrefix()) == 0 {
+			fmt.Fprintf(w, "      no labels or prefixes to resolve\n")
+		} else {
+			subnets, err := r.subnets(ctx, "", selector.GetMatchLabels(), selector.GetMatchPrefix())
+			if err != nil {
+				return err
+			}
+			printSubnets(w, "      ", subnets)
+		}
+		printUnresolved(w, "      ", map[string]bool{
+			"matchExpressions": len(selector.GetMatchExpressions()) > 0,
+		})
+	}
+	if domain != nil {
+		fmt.Fprintf(w, "    network domain:\n")
+		if err := r.printNetworkDomains(ctx, w, "      ", appDomainSelector(domain)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printUnresolved notes the selectors which are only evaluated by the
+// controller.
+func printUnresolved(w io.Writer, indent string, selectors map[string]bool) {
+	var names []string
+	for name, set := range selectors {
+		if set {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "%s%s resolved by the controller\n", indent, strings.Join(names, ", "))
+}
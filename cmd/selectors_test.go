@@ -0,0 +1,56 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainSelectorMatches(t *testing.T) {
+	vpc := networkDomain{Type: "VPC", ID: "vpc-1", Name: "infra"}
+	labels := map[string]string{"env": "sandbox", "team": "net"}
+	tests := []struct {
+		selector domainSelector
+		expected bool
+	}{
+		{domainSelector{}, false},
+		{domainSelector{id: "vpc-1"}, true},
+		{domainSelector{id: "vpc-2"}, false},
+		{domainSelector{kind: "vrf", id: "vpc-1"}, false},
+		{domainSelector{kind: "vpc", name: "infra"}, true},
+		{domainSelector{labels: map[string]string{"env": "sandbox"}}, true},
+		{domainSelector{labels: map[string]string{"env": "prod"}}, false},
+		{domainSelector{id: "vpc-1", labels: map[string]string{"owner": "x"}}, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, tt.selector.matches(vpc, labels), tt.selector.String())
+	}
+}
+
+func TestInPrefixes(t *testing.T) {
+	prefixes := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/16"), netip.MustParsePrefix("192.168.1.0/24")}
+	require.True(t, inPrefixes("10.0.3.0/24", prefixes))
+	require.True(t, inPrefixes("192.168.1.0/24", prefixes))
+	require.False(t, inPrefixes("10.0.0.0/8", prefixes))
+	require.False(t, inPrefixes("172.16.0.0/24", prefixes))
+	require.False(t, inPrefixes("invalid", prefixes))
+	require.True(t, inPrefixes("172.16.0.0/24", nil))
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	tlsFlag                   = controllersFlag + ".tls"
+	tlsEnabledFlag            = tlsFlag + ".enabled"
+	tlsCAFileFlag             = tlsFlag + ".ca_file"
+	tlsServerNameFlag         = tlsFlag + ".server_name"
+	tlsCertFileFlag           = tlsFlag + ".cert_file"
+	tlsKeyFileFlag            = tlsFlag + ".key_file"
+	tlsInsecureSkipVerifyFlag = tlsFlag + ".insecure_skip_verify"
+)
+
+// grpcTransportCredentials returns the credentials used to dial the
+// controller or the proxy. Plaintext is used unless TLS is enabled in the
+// connection settings of the controller.
+func grpcTransportCredentials() (credentials.TransportCredentials, error) {
+	if !viper.GetBool(tlsEnabledFlag) {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := grpcTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not configure TLS: %v", err)
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// grpcTLSConfig builds the TLS configuration from the config file. The
+// system certificate pool is used unless a CA bundle is given, and a
+// client certificate is presented when both certificate and key are set.
+func grpcTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         viper.GetString(tlsServerNameFlag),
+		InsecureSkipVerify: viper.GetBool(tlsInsecureSkipVerifyFlag),
+	}
+	if caFile := viper.GetString(tlsCAFileFlag); caFile != "" {
+		bundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	certFile, keyFile := viper.GetString(tlsCertFileFlag), viper.GetString(tlsKeyFileFlag)
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("both %s and %s are required for mutual TLS", tlsCertFileFlag, tlsKeyFileFlag)
+		}
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// testCertificate issues a certificate signed by parent, or a self-signed
+// CA certificate when parent is nil.
+func testCertificate(t *testing.T, name string, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, any(key)
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func writePEM(t *testing.T, path, kind string, der []byte) string {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+	return path
+}
+
+func TestGRPCClientMutualTLS(t *testing.T) {
+	t.Cleanup(resetConfig)
+	dir := t.TempDir()
+	ca := testCertificate(t, "ca", nil)
+	server := testCertificate(t, "awi.test", &ca)
+	client := testCertificate(t, "cli", &ca)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{server},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	defer s.Stop()
+
+	clientKey, err := x509.MarshalECPrivateKey(client.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	viper.Set(urlFlag, listener.Addr().String())
+	viper.Set(tlsEnabledFlag, true)
+	viper.Set(tlsCAFileFlag, writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", ca.Certificate[0]))
+	viper.Set(tlsServerNameFlag, "awi.test")
+	viper.Set(tlsCertFileFlag, writePEM(t, filepath.Join(dir, "cli.pem"), "CERTIFICATE", client.Certificate[0]))
+	viper.Set(tlsKeyFileFlag, writePEM(t, filepath.Join(dir, "cli-key.pem"), "EC PRIVATE KEY", clientKey))
+
+	conn, err := getGRPCClient()
+	require.NoError(t, err)
+	connClose(conn)
+
+	viper.Set(tlsKeyFileFlag, "")
+	_, err = getGRPCClient()
+	require.EqualError(t, err, "could not configure TLS: both controllers.sdwan.tls.cert_file and controllers.sdwan.tls.key_file are required for mutual TLS")
+}
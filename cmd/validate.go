@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:         "validate",
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	Short:       "Validate manifests without contacting the controller",
+	Long: `Validate manifests against the schema of the AWI API without contacting
+the controller.
+
+Every problem is reported with its file, line, column and field path.
+Unknown fields, values of a wrong type, missing names and selectors,
+malformed port ranges and unknown protocols are detected.`,
+	Args: cobra.NoArgs,
+	RunE: validate,
+}
+
+func validate(cmd *cobra.Command, _ []string) error {
+	recursive, err := cmd.Flags().GetBool(recursiveFlag)
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(cmd.Flag(filenameFlag).Value.String(), recursive)
+	if err != nil {
+		return err
+	}
+	invalid := 0
+	for _, doc := range documents {
+		problems := validateDocument(doc)
+		for _, problem := range problems {
+			fmt.Fprintln(cmd.OutOrStdout(), problem.format(doc.file))
+		}
+		if len(problems) > 0 {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d documents are invalid", invalid, len(documents))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%d documents are valid\n", len(documents))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringP(filenameFlag, "f", "", "Manifest file, directory of manifests or - for standard input")
+	_ = validateCmd.MarkFlagRequired(filenameFlag)
+	validateCmd.Flags().BoolP(recursiveFlag, "R", false, "Process the directory used in -f recursively")
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	waitFlag        = "wait"
+	waitTimeoutFlag = "wait-timeout"
+	forFlag         = "for"
+
+	defaultWaitTimeout = 5 * time.Minute
+)
+
+var (
+	// waitInitialInterval and waitMaxInterval bound the exponential
+	// backoff between status polls.
+	waitInitialInterval = time.Second
+	waitMaxInterval     = 15 * time.Second
+)
+
+// waitCmd represents the wait command
+var waitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait for resources to reach a status",
+	Long: `Wait for resources to reach a status.
+
+The controller is polled with an exponential backoff until the resource
+reaches the status given in --for. The command fails when the resource
+reaches FAILED instead or the --wait-timeout expires.`,
+}
+
+var waitConnectionCmd = &cobra.Command{
+	Use:   "connection <id>",
+	Short: "Wait for a connection to reach a status",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := clients.connectionController()
+		if err != nil {
+			return err
+		}
+		return waitResource(cmd, args[0], connectionStatus(c, args[0]))
+	},
+}
+
+var waitAppConnectionCmd = &cobra.Command{
+	Use:   "app-connection <id>",
+	Short: "Wait for an app connection to reach a status",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := clients.appConnectionController()
+		if err != nil {
+			return err
+		}
+		return waitResource(cmd, args[0], appConnectionStatus(c, args[0]))
+	},
+}
+
+// statusFunc returns the current status of a resource.
+type statusFunc func(ctx context.Context) (awi.Status, error)
+
+func connectionStatus(c awi.ConnectionControllerClient, id string) statusFunc {
+	return func(ctx context.Context) (awi.Status, error) {
+		response, err := c.GetConnectionStatus(ctx, &awi.ConnectionStatusRequest{ConnectionId: id})
+		if err != nil {
+			return 0, err
+		}
+		return response.GetConnectionStatus(), nil
+	}
+}
+
+func appConnectionStatus(c awi.AppConnectionControllerClient, id string) statusFunc {
+	return func(ctx context.Context) (awi.Status, error) {
+		response, err := c.GetAppConnectionStatus(ctx, &awi.GetAppConnectionStatusRequest{ConnectionId: id})
+		if err != nil {
+			return 0, err
+		}
+		return response.GetStatus(), nil
+	}
+}
+
+func waitResource(cmd *cobra.Command, id string, get statusFunc) error {
+	want, err := parseWaitCondition(cmd.Flag(forFlag).Value.String())
+	if err != nil {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration(waitTimeoutFlag)
+	if err != nil {
+		return err
+	}
+
+	s, err := waitForStatus(get, want, timeout)
+	if err != nil {
+		return fmt.Errorf("%s: %v", id, err)
+	}
+	fmt.Printf("%s: Status: %s\n", id, s.String())
+	return nil
+}
+
+// parseWaitCondition parses conditions in the form status=<STATUS>.
+func parseWaitCondition(condition string) (awi.Status, error) {
+	key, value, ok := strings.Cut(condition, "=")
+	if !ok || strings.ToLower(key) != "status" {
+		return 0, fmt.Errorf("unsupported condition %q, expected status=<STATUS>", condition)
+	}
+	s, ok := awi.Status_value[strings.ToUpper(value)]
+	if !ok {
+		return 0, fmt.Errorf("unknown status %q", value)
+	}
+	return awi.Status(s), nil
+}
+
+// waitForStatus polls the status until it becomes want. Reaching FAILED
+// and running out of time are reported as errors. Errors which may be
+// caused by the resource not being visible yet are retried.
+func waitForStatus(get statusFunc, want awi.Status, timeout time.Duration) (awi.Status, error) {
+	deadline := time.Now().Add(timeout)
+	interval := waitInitialInterval
+	var last awi.Status
+	var lastErr error
+	for {
+		ctx, cancel := requestContext()
+		s, err := get(ctx)
+		cancel()
+		switch {
+		case err == nil:
+			last, lastErr = s, nil
+			if s == want {
+				return s, nil
+			}
+			if s == awi.Status_FAILED {
+				return s, fmt.Errorf("reached status %s while waiting for %s", s.String(), want.String())
+			}
+			logger.Infof("status is %s, waiting for %s", s.String(), want.String())
+		case retryableWaitError(err):
+			lastErr = err
+			logger.Infof("could not get status: %v", err)
+		default:
+			return 0, fmt.Errorf("could not get status: %v", err)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			if lastErr != nil {
+				return 0, fmt.Errorf("timed out waiting for status %s: %v", want.String(), lastErr)
+			}
+			return last, fmt.Errorf("timed out waiting for status %s, last status %s", want.String(), last.String())
+		}
+		time.Sleep(min(interval, remaining))
+		interval = min(2*interval, waitMaxInterval)
+	}
+}
+
+func retryableWaitError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// addWaitFlags adds the flags used to wait for created resources.
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(waitFlag, false, "Wait until the created resource reaches status SUCCESS")
+	cmd.Flags().Duration(waitTimeoutFlag, defaultWaitTimeout, "Maximum time to wait for the status")
+}
+
+// waitAfterCreate waits for the created resource when --wait is set.
+func waitAfterCreate(cmd *cobra.Command, get statusFunc) error {
+	wait, err := cmd.Flags().GetBool(waitFlag)
+	if err != nil || !wait {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration(waitTimeoutFlag)
+	if err != nil {
+		return err
+	}
+	s, err := waitForStatus(get, awi.Status_SUCCESS, timeout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Status: %v\n", s.String())
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+	waitCmd.AddCommand(waitConnectionCmd)
+	waitCmd.AddCommand(waitAppConnectionCmd)
+	waitCmd.PersistentFlags().String(forFlag, "status="+awi.Status_SUCCESS.String(), "Condition to wait for, in the form status=<STATUS>")
+	waitCmd.PersistentFlags().Duration(waitTimeoutFlag, defaultWaitTimeout, "Maximum time to wait for the condition")
+}
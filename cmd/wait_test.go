@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	s, err := parseWaitCondition("status=success")
+	require.NoError(t, err)
+	require.Equal(t, awi.Status_SUCCESS, s)
+
+	_, err = parseWaitCondition("status=READY")
+	require.EqualError(t, err, `unknown status "READY"`)
+	_, err = parseWaitCondition("condition=SUCCESS")
+	require.Error(t, err)
+}
+
+func TestWaitForStatus(t *testing.T) {
+	waitInitialInterval, waitMaxInterval = time.Millisecond, 2*time.Millisecond
+	defer func() {
+		waitInitialInterval, waitMaxInterval = time.Second, 15*time.Second
+	}()
+
+	sequence := func(steps ...interface{}) statusFunc {
+		return func(context.Context) (awi.Status, error) {
+			step := steps[0]
+			if len(steps) > 1 {
+				steps = steps[1:]
+			}
+			if err, ok := step.(error); ok {
+				return 0, err
+			}
+			return step.(awi.Status), nil
+		}
+	}
+
+	s, err := waitForStatus(sequence(status.Error(codes.NotFound, "not yet"), awi.Status_IN_PROGRESS, awi.Status_SUCCESS), awi.Status_SUCCESS, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, awi.Status_SUCCESS, s)
+
+	_, err = waitForStatus(sequence(awi.Status_IN_PROGRESS, awi.Status_FAILED), awi.Status_SUCCESS, time.Second)
+	require.EqualError(t, err, "reached status FAILED while waiting for SUCCESS")
+
+	_, err = waitForStatus(sequence(awi.Status_IN_PROGRESS), awi.Status_SUCCESS, 10*time.Millisecond)
+	require.EqualError(t, err, "timed out waiting for status SUCCESS, last status IN_PROGRESS")
+
+	_, err = waitForStatus(sequence(status.Error(codes.PermissionDenied, "denied")), awi.Status_SUCCESS, time.Second)
+	require.Error(t, err)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cloudServer serves the inventory through the Cloud service of
+// awi-grpc.
+type cloudServer struct {
+	awi.UnimplementedCloudServer
+	c *Controller
+}
+
+func (s *cloudServer) ListInstances(_ context.Context, in *awi.ListInstancesRequest) (*awi.ListInstancesResponse, error) {
+	response := &awi.ListInstancesResponse{}
+	for _, instance := range s.c.inventory.instances {
+		if matchFilter(in.GetProvider(), instance.GetProvider()) && matchFilter(in.GetVpc(), instance.GetVpcId()) &&
+			matchFilter(in.GetZone(), instance.GetZone()) && matchFilter(in.GetRegion(), instance.GetRegion()) &&
+			matchLabels(in.GetLabels(), instance.GetLabels()) {
+			response.Instances = append(response.Instances, awiInstance(instance))
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudServer) ListSubnets(_ context.Context, in *awi.ListSubnetRequest) (*awi.ListSubnetResponse, error) {
+	response := &awi.ListSubnetResponse{}
+	for _, subnet := range s.c.inventory.subnets {
+		if matchFilter(in.GetProvider(), subnet.GetProvider()) && matchFilter(in.GetVPCID(), subnet.GetVpcId()) &&
+			matchFilter(in.GetZone(), subnet.GetZone()) && matchFilter(in.GetRegion(), subnet.GetRegion()) &&
+			matchFilter(in.GetCIDR(), subnet.GetCidrBlock()) && matchLabels(in.GetLabels(), subnet.GetLabels()) {
+			response.Subnets = append(response.Subnets, awiSubnet(subnet))
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudServer) ListSites(_ context.Context, _ *awi.ListSiteRequest) (*awi.ListSiteResponse, error) {
+	return &awi.ListSiteResponse{Sites: s.c.inventory.sites}, nil
+}
+
+func (s *cloudServer) ListVPCs(_ context.Context, in *awi.ListVPCRequest) (*awi.ListVPCResponse, error) {
+	response := &awi.ListVPCResponse{}
+	for _, vpc := range s.c.inventory.vpcs {
+		if matchFilter(in.GetProvider(), vpc.GetProvider()) && matchFilter(in.GetRegion(), vpc.GetRegion()) {
+			response.VPCs = append(response.VPCs, awiVPC(vpc))
+		}
+	}
+	return response, nil
+}
+
+// ListVPCTags lists the VPCs carrying a label with the name of the tag.
+func (s *cloudServer) ListVPCTags(_ context.Context, in *awi.ListVPCTagRequest) (*awi.ListVPCResponse, error) {
+	response := &awi.ListVPCResponse{}
+	for _, vpc := range s.c.inventory.vpcs {
+		value, tagged := vpc.GetLabels()[in.GetTag()]
+		if matchFilter(in.GetProvider(), vpc.GetProvider()) && matchFilter(in.GetRegion(), vpc.GetRegion()) &&
+			(in.GetTag() == "" || tagged) {
+			v := awiVPC(vpc)
+			v.Tag = value
+			response.VPCs = append(response.VPCs, v)
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudServer) ListVPNs(_ context.Context, _ *awi.ListVPNRequest) (*awi.ListVPNResponse, error) {
+	return &awi.ListVPNResponse{VPNs: s.c.inventory.vpns}, nil
+}
+
+// cloudProviderServer serves the inventory through the CloudProviderService
+// of awi-infra-guard.
+type cloudProviderServer struct {
+	infrapb.UnimplementedCloudProviderServiceServer
+	c *Controller
+}
+
+func (s *cloudProviderServer) ListAccounts(_ context.Context, in *infrapb.ListAccountsRequest) (*infrapb.ListAccountsResponse, error) {
+	response := &infrapb.ListAccountsResponse{}
+	for _, account := range s.c.inventory.accounts {
+		if matchFilter(in.GetProvider(), account.GetProvider()) {
+			response.Accounts = append(response.Accounts, account)
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudProviderServer) ListVPC(_ context.Context, in *infrapb.ListVPCRequest) (*infrapb.ListVPCResponse, error) {
+	response := &infrapb.ListVPCResponse{LastSyncTime: s.c.timestamp()}
+	for _, vpc := range s.c.inventory.vpcs {
+		if matchFilter(in.GetProvider(), vpc.GetProvider()) && matchFilter(in.GetRegion(), vpc.GetRegion()) &&
+			matchFilter(in.GetAccountId(), vpc.GetAccountId()) && matchLabels(in.GetLabels(), vpc.GetLabels()) {
+			response.Vpcs = append(response.Vpcs, vpc)
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudProviderServer) ListInstances(_ context.Context, in *infrapb.ListInstancesRequest) (*infrapb.ListInstancesResponse, error) {
+	response := &infrapb.ListInstancesResponse{LastSyncTime: s.c.timestamp()}
+	for _, instance := range s.c.inventory.instances {
+		if matchFilter(in.GetProvider(), instance.GetProvider()) && matchFilter(in.GetVpcId(), instance.GetVpcId()) &&
+			matchFilter(in.GetZone(), instance.GetZone()) && matchFilter(in.GetRegion(), instance.GetRegion()) &&
+			matchFilter(in.GetAccountId(), instance.GetAccountId()) && matchLabels(in.GetLabels(), instance.GetLabels()) {
+			response.Instances = append(response.Instances, instance)
+		}
+	}
+	return response, nil
+}
+
+func (s *cloudProviderServer) GetSubnet(_ context.Context, in *infrapb.GetSubnetRequest) (*infrapb.GetSubnetResponse, error) {
+	for _, subnet := range s.c.inventory.subnets {
+		if subnet.GetSubnetId() == in.GetId() && matchFilter(in.GetProvider(), subnet.GetProvider()) {
+			return &infrapb.GetSubnetResponse{Subnet: subnet}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "subnet %q not found", in.GetId())
+}
+
+func (s *cloudProviderServer) ListSubnets(_ context.Context, in *infrapb.ListSubnetsRequest) (*infrapb.ListSubnetsResponse, error) {
+	response := &infrapb.ListSubnetsResponse{LastSyncTime: s.c.timestamp()}
+	for _, subnet := range s.c.inventory.subnets {
+		if matchFilter(in.GetProvider(), subnet.GetProvider()) && matchFilter(in.GetVpcId(), subnet.GetVpcId()) &&
+			matchFilter(in.GetZone(), subnet.GetZone()) && matchFilter(in.GetRegion(), subnet.GetRegion()) &&
+			matchFilter(in.GetCidr(), subnet.GetCidrBlock()) && matchFilter(in.GetAccountId(), subnet.GetAccountId()) &&
+			matchLabels(in.GetLabels(), subnet.GetLabels()) {
+			response.Subnets = append(response.Subnets, subnet)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	connectionKind          = "connection"
+	appConnectionKind       = "app-connection"
+	appConnectionPolicyKind = "app-connection-policy"
+
+	successStatus = "SUCCESS"
+)
+
+type connectionServer struct {
+	awi.UnimplementedConnectionControllerServer
+	c *Controller
+}
+
+func (s *connectionServer) Connect(_ context.Context, in *awi.ConnectionRequest) (*awi.ConnectionResponse, error) {
+	name := in.GetMetadata().GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "connection name is required")
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.findConnection(func(c *awi.ConnectionInformation) bool { return c.GetMetadata().GetName() == name }) >= 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "connection %q already exists", name)
+	}
+
+	spec := in.GetSpec()
+	now := s.c.timestamp()
+	connection := &awi.ConnectionInformation{
+		Id:                    s.c.newID(connectionKind),
+		Metadata:              proto.Clone(in.GetMetadata()).(*awi.ConnectionMetadata),
+		Source:                s.c.inventory.networkDomain(spec.GetSource().GetNetworkDomain().GetSelector()),
+		Destination:           s.c.inventory.networkDomain(spec.GetDestination().GetNetworkDomain().GetSelector()),
+		Config:                proto.Clone(spec).(*awi.NetworkDomainConnectionConfig),
+		Status:                awi.Status_SUCCESS,
+		CreationTimestamp:     now,
+		ModificationTimestamp: now,
+	}
+	if connection.Source == nil {
+		connection.Source = &awi.NetworkDomainObject{Name: spec.GetSource().GetMetadata().GetName()}
+	}
+	if connection.Destination == nil {
+		connection.Destination = &awi.NetworkDomainObject{Name: spec.GetDestination().GetMetadata().GetName()}
+	}
+	s.c.connections = append(s.c.connections, connection)
+	return &awi.ConnectionResponse{ConnectionId: connection.GetId(), Status: connection.GetStatus()}, nil
+}
+
+func (s *connectionServer) Disconnect(_ context.Context, in *awi.DisconnectRequest) (*awi.DisconnectResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findConnection(byConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, connectionNotFound(in.GetConnectionId())
+	}
+	connection := s.c.connections[i]
+	s.c.connections = append(s.c.connections[:i], s.c.connections[i+1:]...)
+	return &awi.DisconnectResponse{
+		ConnectionId:   connection.GetId(),
+		ConnectionName: connection.GetMetadata().GetName(),
+		Status:         awi.Status_SUCCESS,
+	}, nil
+}
+
+func (s *connectionServer) GetConnection(_ context.Context, in *awi.GetConnectionRequest) (*awi.ConnectionResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findConnection(byConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, connectionNotFound(in.GetConnectionId())
+	}
+	return &awi.ConnectionResponse{ConnectionId: in.GetConnectionId(), Status: s.c.connections[i].GetStatus()}, nil
+}
+
+func (s *connectionServer) ListConnections(_ context.Context, _ *awi.ListConnectionsRequest) (*awi.ListConnectionsResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	return &awi.ListConnectionsResponse{
+		Connections: append([]*awi.ConnectionInformation(nil), s.c.connections...),
+	}, nil
+}
+
+func (s *connectionServer) GetConnectionStatus(_ context.Context, in *awi.ConnectionStatusRequest) (*awi.ConnectionStatusResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findConnection(byConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, connectionNotFound(in.GetConnectionId())
+	}
+	return &awi.ConnectionStatusResponse{ConnectionStatus: s.c.connections[i].GetStatus()}, nil
+}
+
+// findConnection returns the index of the first connection matching the
+// function or -1. The caller must hold c.mu.
+func (c *Controller) findConnection(match func(*awi.ConnectionInformation) bool) int {
+	for i, connection := range c.connections {
+		if match(connection) {
+			return i
+		}
+	}
+	return -1
+}
+
+func byConnectionID(id string) func(*awi.ConnectionInformation) bool {
+	return func(c *awi.ConnectionInformation) bool { return c.GetId() == id }
+}
+
+func connectionNotFound(id string) error {
+	return status.Errorf(codes.NotFound, "connection %q not found", id)
+}
+
+type appConnectionServer struct {
+	awi.UnimplementedAppConnectionControllerServer
+	c *Controller
+}
+
+func (s *appConnectionServer) ConnectApps(_ context.Context, in *awi.AppConnection) (*awi.AppConnectionResponse, error) {
+	name := in.GetMetadata().GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "app connection name is required")
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.findAppConnection(func(c *awi.AppConnectionInformation) bool {
+		return c.GetAppConnectionConfig().GetMetadata().GetName() == name
+	}) >= 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "app connection %q already exists", name)
+	}
+
+	config := proto.Clone(in).(*awi.AppConnection)
+	now := s.c.timestamp()
+	config.Metadata.CreationTimestamp = now
+	config.Metadata.ModificationTimestamp = now
+	appConnection := &awi.AppConnectionInformation{
+		Id:                          s.c.newID(appConnectionKind),
+		AppConnectionConfig:         config,
+		Status:                      awi.Status_SUCCESS,
+		NetworkDomainConnectionName: in.GetNetworkDomainConnection().GetSelector().GetMatchName(),
+		SourceMatched:               &awi.MatchedResources{MatchedInstances: s.c.inventory.matchedInstances(in.GetFrom().GetEndpoint())},
+		DestinationMatched:          &awi.MatchedResources{MatchedInstances: s.c.inventory.matchedInstances(in.GetTo().GetEndpoint())},
+	}
+	s.c.appConnections = append(s.c.appConnections, appConnection)
+	return &awi.AppConnectionResponse{
+		AppConnId:   appConnection.GetId(),
+		AppConnName: name,
+		Status:      appConnection.GetStatus(),
+	}, nil
+}
+
+func (s *appConnectionServer) DisconnectApps(_ context.Context, in *awi.AppDisconnectionRequest) (*awi.AppDisconnectionResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAppConnection(byAppConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, appConnectionNotFound(in.GetConnectionId())
+	}
+	appConnection := s.c.appConnections[i]
+	s.c.appConnections = append(s.c.appConnections[:i], s.c.appConnections[i+1:]...)
+	return &awi.AppDisconnectionResponse{
+		ConnectionId:   appConnection.GetId(),
+		ConnectionName: appConnection.GetAppConnectionConfig().GetMetadata().GetName(),
+		Status:         awi.Status_SUCCESS,
+	}, nil
+}
+
+func (s *appConnectionServer) GetAppConnection(_ context.Context, in *awi.GetAppConnectionRequest) (*awi.GetAppConnectionResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAppConnection(byAppConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, appConnectionNotFound(in.GetConnectionId())
+	}
+	return &awi.GetAppConnectionResponse{AppConnection: s.c.appConnections[i]}, nil
+}
+
+func (s *appConnectionServer) ListConnectedApps(_ context.Context, _ *awi.ListAppConnectionsRequest) (*awi.ListAppConnectionsResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	return &awi.ListAppConnectionsResponse{
+		AppConnections: append([]*awi.AppConnectionInformation(nil), s.c.appConnections...),
+	}, nil
+}
+
+func (s *appConnectionServer) GetAppConnectionStatus(_ context.Context, in *awi.GetAppConnectionStatusRequest) (*awi.AppConnectionStatusResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAppConnection(byAppConnectionID(in.GetConnectionId()))
+	if i < 0 {
+		return nil, appConnectionNotFound(in.GetConnectionId())
+	}
+	appConnection := s.c.appConnections[i]
+	return &awi.AppConnectionStatusResponse{
+		AppConnId:   appConnection.GetId(),
+		AppConnName: appConnection.GetAppConnectionConfig().GetMetadata().GetName(),
+		Status:      appConnection.GetStatus(),
+	}, nil
+}
+
+func (s *appConnectionServer) GetMatchedResources(_ context.Context, in *awi.AppConnection) (*awi.GetMatchedResourcesResponse, error) {
+	return &awi.GetMatchedResourcesResponse{
+		SourceMatched:      &awi.MatchedResources{MatchedInstances: s.c.inventory.matchedInstances(in.GetFrom().GetEndpoint())},
+		DestinationMatched: &awi.MatchedResources{MatchedInstances: s.c.inventory.matchedInstances(in.GetTo().GetEndpoint())},
+	}, nil
+}
+
+func (s *appConnectionServer) CreateAppConnectionPolicy(_ context.Context, in *awi.CreateAppConnectionPolicyRequest) (*awi.CreateAppConnectionPolicyResponse, error) {
+	if in.GetAppConnection().GetMetadata().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app connection policy name is required")
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	policy := &awi.AppConnectionPolicy{
+		Id:            s.c.newID(appConnectionPolicyKind),
+		AppConnection: proto.Clone(in.GetAppConnection()).(*awi.AppConnection),
+	}
+	s.c.appConnectionPolicies = append(s.c.appConnectionPolicies, policy)
+	return &awi.CreateAppConnectionPolicyResponse{Status: successStatus, Id: policy.GetId()}, nil
+}
+
+func (s *appConnectionServer) GetAppConnectionPolicy(_ context.Context, in *awi.GetAppConnectionPolicyRequest) (*awi.GetAppConnectionPolicyResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAppConnectionPolicy(in.GetId())
+	if i < 0 {
+		return nil, appConnectionPolicyNotFound(in.GetId())
+	}
+	return &awi.GetAppConnectionPolicyResponse{AppConnectionPolicy: s.c.appConnectionPolicies[i]}, nil
+}
+
+func (s *appConnectionServer) DeleteAppConnectionPolicy(_ context.Context, in *awi.DeleteAppConnectionPolicyRequest) (*awi.DeleteAppConnectionPolicyResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAppConnectionPolicy(in.GetId())
+	if i < 0 {
+		return nil, appConnectionPolicyNotFound(in.GetId())
+	}
+	s.c.appConnectionPolicies = append(s.c.appConnectionPolicies[:i], s.c.appConnectionPolicies[i+1:]...)
+	return &awi.DeleteAppConnectionPolicyResponse{Status: successStatus}, nil
+}
+
+func (s *appConnectionServer) ListAppConnectionPolicies(_ context.Context, _ *awi.ListAppConnectionPoliciesRequest) (*awi.ListAppConnectionPoliciesResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	return &awi.ListAppConnectionPoliciesResponse{
+		AppConnectionPolicies: append([]*awi.AppConnectionPolicy(nil), s.c.appConnectionPolicies...),
+	}, nil
+}
+
+// findAppConnection returns the index of the first app connection
+// matching the function or -1. The caller must hold c.mu.
+func (c *Controller) findAppConnection(match func(*awi.AppConnectionInformation) bool) int {
+	for i, appConnection := range c.appConnections {
+		if match(appConnection) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findAppConnectionPolicy returns the index of the policy with the ID or
+// -1. The caller must hold c.mu.
+func (c *Controller) findAppConnectionPolicy(id string) int {
+	for i, policy := range c.appConnectionPolicies {
+		if policy.GetId() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func byAppConnectionID(id string) func(*awi.AppConnectionInformation) bool {
+	return func(c *awi.AppConnectionInformation) bool { return c.GetId() == id }
+}
+
+func appConnectionNotFound(id string) error {
+	return status.Errorf(codes.NotFound, "app connection %q not found", id)
+}
+
+func appConnectionPolicyNotFound(id string) error {
+	return status.Errorf(codes.NotFound, "app connection policy %q not found", id)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake implements an AWI controller which keeps its state in
+// memory. It serves the awi-grpc and awi-infra-guard APIs used by the CLI
+// over a fixed cloud inventory, so commands can be tested and demoed
+// without a controller or cloud accounts.
+package fake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+	"google.golang.org/grpc"
+)
+
+// Controller holds the state of the fake controller. Created resources
+// reach status SUCCESS immediately.
+type Controller struct {
+	// Now returns the time used for creation timestamps.
+	Now func() time.Time
+
+	mu                    sync.Mutex
+	next                  map[string]int
+	connections           []*awi.ConnectionInformation
+	appConnections        []*awi.AppConnectionInformation
+	appConnectionPolicies []*awi.AppConnectionPolicy
+	accessPolicies        []*awi.Security_AccessPolicy
+	networkSLAs           []*awi.NetworkSLA
+
+	inventory inventory
+}
+
+// NewController returns a controller without resources and with the
+// default cloud inventory.
+func NewController() *Controller {
+	return &Controller{
+		Now:       time.Now,
+		next:      map[string]int{},
+		inventory: defaultInventory(),
+	}
+}
+
+// NewServer returns a gRPC server serving all services of the controller.
+func NewServer(c *Controller, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	c.Register(s)
+	return s
+}
+
+// Register registers all services of the controller on the server.
+func (c *Controller) Register(s grpc.ServiceRegistrar) {
+	awi.RegisterConnectionControllerServer(s, &connectionServer{c: c})
+	awi.RegisterAppConnectionControllerServer(s, &appConnectionServer{c: c})
+	awi.RegisterSecurityPolicyServiceServer(s, &securityPolicyServer{c: c})
+	awi.RegisterNetworkSLAServiceServer(s, &networkSLAServer{c: c})
+	awi.RegisterCloudServer(s, &cloudServer{c: c})
+	infrapb.RegisterCloudProviderServiceServer(s, &cloudProviderServer{c: c})
+}
+
+// newID returns the next ID of the kind of resource. The caller must hold
+// c.mu.
+func (c *Controller) newID(kind string) string {
+	c.next[kind]++
+	return fmt.Sprintf("%s-%d", kind, c.next[kind])
+}
+
+// timestamp returns the current time in the format used by the
+// controller.
+func (c *Controller) timestamp() string {
+	return c.Now().UTC().Format(time.RFC3339)
+}
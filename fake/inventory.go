@@ -0,0 +1,234 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"strings"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"github.com/app-net-interface/awi-infra-guard/grpc/go/infrapb"
+)
+
+const (
+	awsProvider   = "aws"
+	gcpProvider   = "gcp"
+	vrfProvider   = "Cisco-SDWAN-vManage"
+	vpcDomainType = "VPC"
+	vrfDomainType = "VRF"
+)
+
+// inventory is the cloud and SD-WAN inventory of the fake controller. It
+// is never modified, so it is read without locking.
+type inventory struct {
+	accounts  []*infrapb.Account
+	vpcs      []*infrapb.VPC
+	subnets   []*infrapb.Subnet
+	instances []*infrapb.Instance
+	vpns      []*awi.VPN
+	sites     []*awi.SiteDetail
+}
+
+// defaultInventory returns an inventory with VPCs in two providers and
+// the VPC IDs and labels used by the examples.
+func defaultInventory() inventory {
+	return inventory{
+		accounts: []*infrapb.Account{
+			{Provider: awsProvider, Id: "123456789012", Name: "awi-aws"},
+			{Provider: gcpProvider, Id: "awi-gcp-project", Name: "awi-gcp"},
+		},
+		vpcs: []*infrapb.VPC{
+			{
+				Id: "vpc-0fe7d06b468142a7e", Name: "awi-staging", Region: "us-east-1",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"env": "staging"},
+			},
+			{
+				Id: "vpc-0a3c5e7f9b1d2e4f6", Name: "awi-development", Region: "us-west-2",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"env": "development"},
+			},
+			{
+				Id: "vpc-0b1c2d3e4f5a6b7c8", Name: "awi-infra", Region: "us-east-1",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"name": "infra"},
+			},
+			{
+				Id: "4815162342108", Name: "awi-sandbox", Region: "us-west1",
+				Provider: gcpProvider, AccountId: "awi-gcp-project",
+				Labels: map[string]string{"env": "sandbox"},
+			},
+		},
+		subnets: []*infrapb.Subnet{
+			{
+				SubnetId: "subnet-0c1d2e3f4a5b6c7d8", Name: "staging-db", CidrBlock: "10.1.1.0/24",
+				VpcId: "vpc-0fe7d06b468142a7e", Zone: "us-east-1a", Region: "us-east-1",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"environment": "staging", "app_type": "database"},
+			},
+			{
+				SubnetId: "subnet-0d2e3f4a5b6c7d8e9", Name: "development-db", CidrBlock: "10.2.1.0/24",
+				VpcId: "vpc-0a3c5e7f9b1d2e4f6", Zone: "us-west-2a", Region: "us-west-2",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"environment": "development", "app_type": "database"},
+			},
+			{
+				SubnetId: "subnet-0e3f4a5b6c7d8e9f0", Name: "infra", CidrBlock: "10.3.1.0/24",
+				VpcId: "vpc-0b1c2d3e4f5a6b7c8", Zone: "us-east-1b", Region: "us-east-1",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"name": "infra"},
+			},
+			{
+				SubnetId: "7301864201357", Name: "sandbox", CidrBlock: "10.4.1.0/24",
+				VpcId: "4815162342108", Zone: "us-west1-a", Region: "us-west1",
+				Provider: gcpProvider, AccountId: "awi-gcp-project",
+				Labels: map[string]string{"env": "sandbox"},
+			},
+		},
+		instances: []*infrapb.Instance{
+			{
+				Id: "i-0a1b2c3d4e5f60718", Name: "staging-db-1", PrivateIP: "10.1.1.10",
+				SubnetID: "subnet-0c1d2e3f4a5b6c7d8", VpcId: "vpc-0fe7d06b468142a7e",
+				Zone: "us-east-1a", Region: "us-east-1", State: "running",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"environment": "staging", "app_type": "database"},
+			},
+			{
+				Id: "i-0b2c3d4e5f6071829", Name: "development-db-1", PrivateIP: "10.2.1.10",
+				SubnetID: "subnet-0d2e3f4a5b6c7d8e9", VpcId: "vpc-0a3c5e7f9b1d2e4f6",
+				Zone: "us-west-2a", Region: "us-west-2", State: "running",
+				Provider: awsProvider, AccountId: "123456789012",
+				Labels: map[string]string{"environment": "development", "app_type": "database"},
+			},
+			{
+				Id: "5928374619283746", Name: "sandbox-web-1", PublicIP: "34.82.10.20", PrivateIP: "10.4.1.10",
+				SubnetID: "7301864201357", VpcId: "4815162342108",
+				Zone: "us-west1-a", Region: "us-west1", State: "running",
+				Provider: gcpProvider, AccountId: "awi-gcp-project",
+				Labels: map[string]string{"env": "sandbox", "app": "web"},
+			},
+		},
+		vpns: []*awi.VPN{
+			{ID: "vpn-10", SegmentName: "corporate", SegmentID: "10"},
+			{ID: "vpn-20", SegmentName: "guest", SegmentID: "20"},
+		},
+		sites: []*awi.SiteDetail{
+			{ID: "site-100", Name: "branch-sjc", IP: "10.255.0.1", SiteID: "100"},
+			{ID: "site-200", Name: "datacenter-rtp", IP: "10.255.0.2", SiteID: "200"},
+		},
+	}
+}
+
+// networkDomain returns the first VRF or VPC matched by the selector.
+func (inv inventory) networkDomain(s *awi.NetworkDomainConnectionConfig_Selector) *awi.NetworkDomainObject {
+	if s == nil {
+		return nil
+	}
+	id, name := s.GetMatchId().GetId(), s.GetMatchName().GetName()
+	if id == "" && name == "" && len(s.GetMatchLabels()) == 0 {
+		return nil
+	}
+	for _, vpn := range inv.vpns {
+		if len(s.GetMatchLabels()) == 0 && (id == "" || id == vpn.GetSegmentID()) && (name == "" || name == vpn.GetSegmentName()) {
+			return &awi.NetworkDomainObject{
+				Type:     vrfDomainType,
+				Provider: vrfProvider,
+				Id:       vpn.GetSegmentID(),
+				Name:     vpn.GetSegmentName(),
+			}
+		}
+	}
+	for _, vpc := range inv.vpcs {
+		if (id == "" || id == vpc.GetId()) && (name == "" || name == vpc.GetName()) && matchLabels(s.GetMatchLabels(), vpc.GetLabels()) {
+			return &awi.NetworkDomainObject{
+				Type:      vpcDomainType,
+				Provider:  vpc.GetProvider(),
+				Id:        vpc.GetId(),
+				Name:      vpc.GetName(),
+				AccountId: vpc.GetAccountId(),
+				Labels:    vpc.GetLabels(),
+			}
+		}
+	}
+	return nil
+}
+
+// matchedInstances returns the instances carrying the labels of the
+// endpoint selector.
+func (inv inventory) matchedInstances(e *awi.Endpoint) []*awi.Instance {
+	labels := e.GetSelector().GetMatchLabels()
+	if len(labels) == 0 {
+		return nil
+	}
+	var matched []*awi.Instance
+	for _, instance := range inv.instances {
+		if matchLabels(labels, instance.GetLabels()) {
+			matched = append(matched, awiInstance(instance))
+		}
+	}
+	return matched
+}
+
+// matchLabels reports whether the labels contain all of the selector.
+func matchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFilter reports whether the value matches a filter of a list
+// request. Empty filters match all values.
+func matchFilter(filter, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+func awiVPC(vpc *infrapb.VPC) *awi.VPC {
+	return &awi.VPC{
+		ID:       vpc.GetId(),
+		Name:     vpc.GetName(),
+		Region:   vpc.GetRegion(),
+		Provider: vpc.GetProvider(),
+		Labels:   vpc.GetLabels(),
+	}
+}
+
+func awiSubnet(subnet *infrapb.Subnet) *awi.Subnet {
+	return &awi.Subnet{
+		SubnetId:  subnet.GetSubnetId(),
+		CidrBlock: subnet.GetCidrBlock(),
+		VpcId:     subnet.GetVpcId(),
+		Zone:      subnet.GetZone(),
+		Name:      subnet.GetName(),
+		Labels:    subnet.GetLabels(),
+	}
+}
+
+func awiInstance(instance *infrapb.Instance) *awi.Instance {
+	return &awi.Instance{
+		ID:        instance.GetId(),
+		Name:      instance.GetName(),
+		PublicIP:  instance.GetPublicIP(),
+		PrivateIP: instance.GetPrivateIP(),
+		SubnetID:  instance.GetSubnetID(),
+		VPCID:     instance.GetVpcId(),
+		State:     instance.GetState(),
+		Labels:    instance.GetLabels(),
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	awi "github.com/app-net-interface/awi-grpc/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// securityPolicyServer keeps access policies, which are identified by
+// their name.
+type securityPolicyServer struct {
+	awi.UnimplementedSecurityPolicyServiceServer
+	c *Controller
+}
+
+func (s *securityPolicyServer) CreateAccessPolicy(_ context.Context, in *awi.AccessPolicyCreateRequest) (*awi.AccessPolicyCreateResponse, error) {
+	name := in.GetAccessPolicy().GetMetadata().GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "access policy name is required")
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.findAccessPolicy(name) >= 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "access policy %q already exists", name)
+	}
+	s.c.accessPolicies = append(s.c.accessPolicies, proto.Clone(in.GetAccessPolicy()).(*awi.Security_AccessPolicy))
+	return &awi.AccessPolicyCreateResponse{Status: awi.Status_SUCCESS}, nil
+}
+
+func (s *securityPolicyServer) DeleteAccessPolicy(_ context.Context, in *awi.AccessPolicyDeleteRequest) (*awi.AccessPolicyDeleteResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findAccessPolicy(in.GetName())
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "access policy %q not found", in.GetName())
+	}
+	s.c.accessPolicies = append(s.c.accessPolicies[:i], s.c.accessPolicies[i+1:]...)
+	return &awi.AccessPolicyDeleteResponse{}, nil
+}
+
+func (s *securityPolicyServer) ListAccessPolicies(_ context.Context, _ *awi.AccessPolicyListRequest) (*awi.AccessPolicyListResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	return &awi.AccessPolicyListResponse{
+		AccessPolicies: append([]*awi.Security_AccessPolicy(nil), s.c.accessPolicies...),
+	}, nil
+}
+
+// findAccessPolicy returns the index of the access policy with the name or
+// -1. The caller must hold c.mu.
+func (c *Controller) findAccessPolicy(name string) int {
+	for i, policy := range c.accessPolicies {
+		if policy.GetMetadata().GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// networkSLAServer keeps network SLAs, which are identified by their
+// name.
+type networkSLAServer struct {
+	awi.UnimplementedNetworkSLAServiceServer
+	c *Controller
+}
+
+func (s *networkSLAServer) CreateNetworkSLA(_ context.Context, in *awi.NetworkSLA) (*awi.NetworkSLACreateResponse, error) {
+	name := in.GetMetadata().GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "network SLA name is required")
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.findNetworkSLA(name) >= 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "network SLA %q already exists", name)
+	}
+	sla := proto.Clone(in).(*awi.NetworkSLA)
+	now := s.c.timestamp()
+	sla.Metadata.CreationTimestamp = now
+	sla.Metadata.ModificationTimestamp = now
+	s.c.networkSLAs = append(s.c.networkSLAs, sla)
+	return &awi.NetworkSLACreateResponse{Status: awi.Status_SUCCESS}, nil
+}
+
+func (s *networkSLAServer) DeleteNetworkSLA(_ context.Context, in *awi.NetworkSLADeleteRequest) (*awi.NetworkSLADeleteResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	i := s.c.findNetworkSLA(in.GetName())
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "network SLA %q not found", in.GetName())
+	}
+	s.c.networkSLAs = append(s.c.networkSLAs[:i], s.c.networkSLAs[i+1:]...)
+	return &awi.NetworkSLADeleteResponse{}, nil
+}
+
+func (s *networkSLAServer) ListNetworkSLAs(_ context.Context, _ *awi.NetworkSLAListReqest) (*awi.NetworkSLAListResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	return &awi.NetworkSLAListResponse{
+		NetworkSLAs: append([]*awi.NetworkSLA(nil), s.c.networkSLAs...),
+	}, nil
+}
+
+// findNetworkSLA returns the index of the network SLA with the name or -1.
+// The caller must hold c.mu.
+func (c *Controller) findNetworkSLA(name string) int {
+	for i, sla := range c.networkSLAs {
+		if sla.GetMetadata().GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
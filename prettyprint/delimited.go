@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+const (
+	csvFormat      = "csv"
+	tsvFormat      = "tsv"
+	markdownFormat = "markdown"
+)
+
+var (
+	// tsvEscaper escapes the characters which would break the rows and
+	// columns of TSV output.
+	tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+	// markdownEscaper escapes the characters which would break the cells
+	// of Markdown tables.
+	markdownEscaper = strings.NewReplacer("\\", "\\\\", "|", "\\|", "\r\n", "<br>", "\n", "<br>")
+)
+
+// getDelimitedFormat renders the table as CSV, TSV or a Markdown table,
+// which, unlike the aligned table, keep values containing spaces intact.
+func getDelimitedFormat(headers []string, rows [][]string, format string) (string, error) {
+	var buffer strings.Builder
+	switch format {
+	case csvFormat:
+		writer := csv.NewWriter(&buffer)
+		if err := writer.Write(headers); err != nil {
+			return "", err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return "", err
+		}
+	case tsvFormat:
+		writeDelimitedRow(&buffer, headers, "", "\t", "", tsvEscaper)
+		for _, row := range rows {
+			writeDelimitedRow(&buffer, row, "", "\t", "", tsvEscaper)
+		}
+	case markdownFormat:
+		writeDelimitedRow(&buffer, headers, "| ", " | ", " |", markdownEscaper)
+		separator := make([]string, len(headers))
+		for i := range separator {
+			separator[i] = "---"
+		}
+		writeDelimitedRow(&buffer, separator, "| ", " | ", " |", markdownEscaper)
+		for _, row := range rows {
+			writeDelimitedRow(&buffer, row, "| ", " | ", " |", markdownEscaper)
+		}
+	default:
+		return "", fmt.Errorf("unsupported output format %q", format)
+	}
+	return buffer.String(), nil
+}
+
+func writeDelimitedRow(buffer *strings.Builder, values []string, prefix, separator, suffix string,
+	escaper *strings.Replacer) {
+	buffer.WriteString(prefix)
+	for i, value := range values {
+		if i > 0 {
+			buffer.WriteString(separator)
+		}
+		buffer.WriteString(escaper.Replace(value))
+	}
+	buffer.WriteString(suffix)
+	buffer.WriteString("\n")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelimitedFormat(t *testing.T) {
+	type Data struct {
+		Name        string
+		Description string
+	}
+	data := []Data{
+		{Name: "gold", Description: "Customer-Facing, low latency"},
+		{Name: "silver", Description: "say \"hi\" | tab\there\nnext line"},
+	}
+	displays := []Display{
+		{Name: "Name", Display: "NAME"},
+		{Name: "Description", Display: "DESCRIPTION"},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: csvFormat,
+			expected: `NAME,DESCRIPTION
+gold,"Customer-Facing, low latency"
+silver,"say ""hi"" | tab	here
+next line"
+`,
+		},
+		{
+			format: tsvFormat,
+			expected: `NAME	DESCRIPTION
+gold	Customer-Facing, low latency
+silver	say "hi" | tab\there\nnext line
+`,
+		},
+		{
+			format: markdownFormat,
+			expected: `| NAME | DESCRIPTION |
+| --- | --- |
+| gold | Customer-Facing, low latency |
+| silver | say "hi" \| tab	here<br>next line |
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			headers, rows := tableRows(data, displays)
+			result, err := getDelimitedFormat(headers, rows, tt.format)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,465 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a path expression evaluated against the generic JSON
+// representation of the printed data, following the kubectl dialect:
+// .field, ['field'], [index], [start:end], [*], ..field and
+// [?(@.field == "value")].
+type jsonPath struct {
+	steps []pathStep
+}
+
+type pathStep interface {
+	apply(values []any) []any
+}
+
+func parseJSONPath(expression string) (*jsonPath, error) {
+	p := &jsonPath{}
+	rest := strings.TrimSpace(expression)
+	rest = strings.TrimPrefix(rest, "$")
+	rest = strings.TrimPrefix(rest, "@")
+	for rest != "" {
+		var step pathStep
+		var err error
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			var name string
+			name, rest = splitName(rest[2:])
+			if name == "" {
+				return nil, fmt.Errorf("missing field name after .. in %q", expression)
+			}
+			step = recursiveStep{name: name}
+		case strings.HasPrefix(rest, ".["):
+			rest = rest[1:]
+			continue
+		case strings.HasPrefix(rest, "."):
+			var name string
+			name, rest = splitName(rest[1:])
+			switch name {
+			case "":
+				if rest != "" {
+					return nil, fmt.Errorf("missing field name in %q", expression)
+				}
+				continue
+			case "*":
+				step = wildcardStep{}
+			default:
+				step = fieldStep{name: name}
+			}
+		case strings.HasPrefix(rest, "["):
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ in %q", expression)
+			}
+			step, err = parseSubscript(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid subscript in %q: %v", expression, err)
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest, expression)
+		}
+		p.steps = append(p.steps, step)
+	}
+	return p, nil
+}
+
+// evaluate returns all values the path matches in the root value.
+func (p *jsonPath) evaluate(root any) []any {
+	values := []any{root}
+	for _, step := range p.steps {
+		values = step.apply(values)
+	}
+	return values
+}
+
+// splitName splits a field name, or *, from the beginning of the path.
+func splitName(path string) (string, string) {
+	if strings.HasPrefix(path, "*") {
+		return "*", path[1:]
+	}
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return path, ""
+	}
+	return path[:end], path[end:]
+}
+
+// closingBracket returns the index of the ] matching the [ at the beginning
+// of the path, skipping brackets in quoted strings and nested filters.
+func closingBracket(path string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseSubscript(subscript string) (pathStep, error) {
+	subscript = strings.TrimSpace(subscript)
+	switch {
+	case subscript == "*":
+		return wildcardStep{}, nil
+	case strings.HasPrefix(subscript, "?(") && strings.HasSuffix(subscript, ")"):
+		return parseFilter(subscript[2 : len(subscript)-1])
+	case isQuoted(subscript):
+		return fieldStep{name: subscript[1 : len(subscript)-1]}, nil
+	case strings.Contains(subscript, ":"):
+		bounds := strings.SplitN(subscript, ":", 2)
+		step := sliceStep{}
+		var err error
+		if step.start, err = parseBound(bounds[0]); err != nil {
+			return nil, err
+		}
+		if step.end, err = parseBound(bounds[1]); err != nil {
+			return nil, err
+		}
+		return step, nil
+	}
+	index, err := strconv.Atoi(subscript)
+	if err != nil {
+		return nil, fmt.Errorf("expected index, got %q", subscript)
+	}
+	return indexStep{index: index}, nil
+}
+
+func parseBound(bound string) (*int, error) {
+	bound = strings.TrimSpace(bound)
+	if bound == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(bound)
+	if err != nil {
+		return nil, fmt.Errorf("expected index, got %q", bound)
+	}
+	return &i, nil
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
+
+type fieldStep struct {
+	name string
+}
+
+func (s fieldStep) apply(values []any) []any {
+	var result []any
+	for _, v := range values {
+		if object, ok := v.(map[string]any); ok {
+			if field, ok := object[s.name]; ok {
+				result = append(result, field)
+			}
+		}
+	}
+	return result
+}
+
+type wildcardStep struct{}
+
+func (wildcardStep) apply(values []any) []any {
+	var result []any
+	for _, v := range values {
+		result = append(result, children(v)...)
+	}
+	return result
+}
+
+// children returns the elements of an array or the values of an object in
+// the order of their keys.
+func children(v any) []any {
+	switch v := v.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make([]any, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, v[key])
+		}
+		return result
+	}
+	return nil
+}
+
+type indexStep struct {
+	index int
+}
+
+func (s indexStep) apply(values []any) []any {
+	var result []any
+	for _, v := range values {
+		array, ok := v.([]any)
+		if !ok {
+			continue
+		}
+		i := s.index
+		if i < 0 {
+			i += len(array)
+		}
+		if i >= 0 && i < len(array) {
+			result = append(result, array[i])
+		}
+	}
+	return result
+}
+
+type sliceStep struct {
+	start *int
+	end   *int
+}
+
+func (s sliceStep) apply(values []any) []any {
+	var result []any
+	for _, v := range values {
+		array, ok := v.([]any)
+		if !ok {
+			continue
+		}
+		start, end := sliceBound(s.start, 0, len(array)), sliceBound(s.end, len(array), len(array))
+		if start < end {
+			result = append(result, array[start:end]...)
+		}
+	}
+	return result
+}
+
+func sliceBound(bound *int, def, length int) int {
+	if bound == nil {
+		return def
+	}
+	i := *bound
+	if i < 0 {
+		i += length
+	}
+	return min(max(i, 0), length)
+}
+
+// recursiveStep matches the field, or every value for *, at any depth.
+type recursiveStep struct {
+	name string
+}
+
+func (s recursiveStep) apply(values []any) []any {
+	var all []any
+	var walk func(v any)
+	walk = func(v any) {
+		all = append(all, v)
+		for _, child := range children(v) {
+			walk(child)
+		}
+	}
+	for _, v := range values {
+		walk(v)
+	}
+	if s.name == "*" {
+		var result []any
+		for _, v := range all {
+			result = append(result, children(v)...)
+		}
+		return result
+	}
+	return fieldStep{name: s.name}.apply(all)
+}
+
+// filterStep keeps the elements of arrays for which the path exists or,
+// with an operator, compares equal to the literal.
+type filterStep struct {
+	path     *jsonPath
+	operator string
+	literal  any
+}
+
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(filter string) (pathStep, error) {
+	step := filterStep{}
+	expression := filter
+	if i, operator := findOperator(filter); i >= 0 {
+		step.operator = operator
+		expression = filter[:i]
+		literal, err := parseLiteral(strings.TrimSpace(filter[i+len(operator):]))
+		if err != nil {
+			return nil, err
+		}
+		step.literal = literal
+	}
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "@") {
+		return nil, fmt.Errorf("filter must start with @, got %q", expression)
+	}
+	path, err := parseJSONPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	step.path = path
+	return step, nil
+}
+
+// findOperator returns the position of the first comparison operator in
+// the filter outside of quoted strings, or -1 if there is none.
+func findOperator(filter string) (int, string) {
+	var quote byte
+	for i := 0; i < len(filter); i++ {
+		c := filter[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		default:
+			for _, operator := range filterOperators {
+				if strings.HasPrefix(filter[i:], operator) {
+					return i, operator
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+func parseLiteral(literal string) (any, error) {
+	switch {
+	case isQuoted(literal):
+		return literal[1 : len(literal)-1], nil
+	case literal == "true" || literal == "false":
+		return literal == "true", nil
+	}
+	if _, err := strconv.ParseFloat(literal, 64); err != nil {
+		return nil, fmt.Errorf("expected string, number or boolean, got %q", literal)
+	}
+	return json.Number(literal), nil
+}
+
+func (s filterStep) apply(values []any) []any {
+	var result []any
+	for _, v := range values {
+		for _, element := range children(v) {
+			if s.matches(element) {
+				result = append(result, element)
+			}
+		}
+	}
+	return result
+}
+
+func (s filterStep) matches(element any) bool {
+	for _, value := range s.path.evaluate(element) {
+		if s.operator == "" || compare(value, s.operator, s.literal) {
+			return true
+		}
+	}
+	return false
+}
+
+func compare(value any, operator string, literal any) bool {
+	var order int
+	switch literal := literal.(type) {
+	case json.Number:
+		a, errA := strconv.ParseFloat(formatValue(value), 64)
+		b, errB := literal.Float64()
+		if errA != nil || errB != nil {
+			return operator == "!="
+		}
+		order = cmpFloat(a, b)
+	case bool:
+		b, ok := value.(bool)
+		if !ok || (operator != "==" && operator != "!=") {
+			return operator == "!="
+		}
+		if b != literal {
+			order = 1
+		}
+	default:
+		order = strings.Compare(formatValue(value), fmt.Sprint(literal))
+	}
+	switch operator {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	}
+	return false
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// formatValue prints strings and numbers as they are and other values as
+// JSON.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
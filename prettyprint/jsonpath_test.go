@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const connectionsDocument = `[
+	{"id": "connection-1", "metadata": {"name": "staging", "labels": {"env": "staging"}},
+	 "source": {"id": "vpc-1"}, "status": "SUCCESS", "priority": 10},
+	{"id": "connection-2", "metadata": {"name": "development"},
+	 "source": {"id": "vpc-2"}, "status": "FAILED", "priority": 2}
+]`
+
+func TestJSONPath(t *testing.T) {
+	value, err := toGeneric(json.RawMessage(connectionsDocument))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected []any
+	}{
+		{path: "[0].id", expected: []any{"connection-1"}},
+		{path: "$[*].metadata.name", expected: []any{"staging", "development"}},
+		{path: ".[-1]['id']", expected: []any{"connection-2"}},
+		{path: "[0:1].id", expected: []any{"connection-1"}},
+		{path: "..env", expected: []any{"staging"}},
+		{path: `[?(@.status == "FAILED")].id`, expected: []any{"connection-2"}},
+		{path: `[?(@.priority > 5)].id`, expected: []any{"connection-1"}},
+		{path: `[?(@.metadata.labels)].id`, expected: []any{"connection-1"}},
+		{path: `[?(@.status != "x==y")].id`, expected: []any{"connection-1", "connection-2"}},
+		{path: `[?(@.status == 'a<b')].id`, expected: nil},
+		{path: `[?(@.priority <= 2)].id`, expected: []any{"connection-2"}},
+		{path: "[*].missing", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := parseJSONPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, path.evaluate(value))
+		})
+	}
+
+	for _, invalid := range []string{"[0", "[x]", "..", "[?(.id)]", "id"} {
+		_, err := parseJSONPath(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestTemplateFormats(t *testing.T) {
+	value, err := toGeneric(json.RawMessage(connectionsDocument))
+	require.NoError(t, err)
+	items := value.([]any)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: "custom-columns=ID:.id,NAME:.metadata.name,ENV:{.metadata.labels.env}",
+			expected: `ID             NAME          ENV
+connection-1   staging       staging
+connection-2   development   <none>
+`,
+		},
+		{
+			format:   `jsonpath={range [*]}{.id}{"\t"}{.status}{"\n"}{end}`,
+			expected: "connection-1\tSUCCESS\nconnection-2\tFAILED\n",
+		},
+		{
+			format:   "jsonpath={[*].metadata.name}",
+			expected: "staging development",
+		},
+		{
+			format:   `go-template={{range .}}{{.metadata.name}}={{.priority}}{{"\n"}}{{end}}`,
+			expected: "staging=10\ndevelopment=2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			result, ok, err := getTemplateFormat(value, items, tt.format)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	for _, invalid := range []string{"custom-columns=", "custom-columns=.id", "jsonpath={range [*]}{.id}", "go-template={{.id"} {
+		_, ok, err := getTemplateFormat(value, items, invalid)
+		require.True(t, ok)
+		require.Error(t, err, invalid)
+	}
+	_, ok, _ := getTemplateFormat(value, items, "xml")
+	require.False(t, ok)
+}
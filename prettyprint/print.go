@@ -20,9 +20,16 @@ package prettyprint
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -32,70 +39,241 @@ const (
 type Display struct {
 	Name    string
 	Display string
+	// Wide columns are only printed in wide output.
+	Wide bool
+	// Color returns the ANSI color of the value in tables or an empty
+	// string to leave it uncolored.
+	Color func(value string) string
 }
 
 const (
-	jsonFormat string = "json"
+	jsonFormat  string = "json"
+	yamlFormat  string = "yaml"
+	wideFormat  string = "wide"
+	tableFormat string = "table"
 )
 
-func PrintData[T any](data []T, displays []Display, format string) {
-	if format == jsonFormat {
-		fmt.Println(getJsonFormat(data))
-	} else {
-		fmt.Println(getPrettyFormat(data, displays))
+var output io.Writer = os.Stdout
+
+// SetOutput sets the writer the data is printed to.
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// IsTable reports whether the output format prints a table.
+func IsTable(format string) bool {
+	name, _ := splitFormat(format)
+	return format == "" || format == tableFormat || format == wideFormat || name == customColumnsFormat
+}
+
+// PrintsRows reports whether PrintConvertedData prints the converted data,
+// as a table or delimited values, in the output format.
+func PrintsRows(format string) bool {
+	switch format {
+	case "", tableFormat, wideFormat, csvFormat, tsvFormat, markdownFormat:
+		return true
+	}
+	return false
+}
+
+// PrintData prints the data as a table with the given columns or in the
+// given output format.
+func PrintData[T any](data []T, displays []Display, format string) error {
+	return PrintConvertedData(data, data, displays, format)
+}
+
+// PrintConvertedData prints the converted data as a table with the given
+// columns, by default or in table output. Other output formats print the
+// original data.
+func PrintConvertedData[T, C any](data []T, convertedData []C, displays []Display, format string) error {
+	switch format {
+	case "", tableFormat, wideFormat:
+		headers, rows := tableRows(convertedData, visibleDisplays(displays, format == wideFormat))
+		if err := sortRows(headers, rows); err != nil {
+			return err
+		}
+		fmt.Fprintln(output, renderTable(headers, rows, columnColors(visibleDisplays(displays, format == wideFormat))))
+	case csvFormat, tsvFormat, markdownFormat:
+		headers, rows := tableRows(convertedData, visibleDisplays(displays, false))
+		if err := sortRows(headers, rows); err != nil {
+			return err
+		}
+		result, err := getDelimitedFormat(headers, rows, format)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(output, result)
+	case jsonFormat:
+		fmt.Fprintln(output, getJsonFormat(data))
+	case yamlFormat:
+		PrintDocuments(data)
+	default:
+		values, err := toGenericList(data)
+		if err != nil {
+			return fmt.Errorf("could not parse data: %v", err)
+		}
+		return printTemplate(values, values, format)
 	}
+	return nil
 }
 
-func PrintConvertedData[T, C any](data []T, convertedData []C, displays []Display, format string) {
-	if format == jsonFormat {
-		fmt.Println(getJsonFormat(data))
+// PrintObject prints a single value in the given output format.
+func PrintObject(d any, format string) error {
+	switch format {
+	case jsonFormat:
+		PrintJSON(d)
+	case yamlFormat:
+		PrintDocuments([]any{d})
+	default:
+		value, err := toGeneric(d)
+		if err != nil {
+			return fmt.Errorf("could not parse data: %v", err)
+		}
+		return printTemplate(value, []any{value}, format)
+	}
+	return nil
+}
+
+func printTemplate(value any, items []any, format string) error {
+	result, ok, err := getTemplateFormat(value, items, format)
+	if !ok {
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	if name, _ := splitFormat(format); name == customColumnsFormat {
+		fmt.Fprintln(output, result)
 	} else {
-		fmt.Println(getPrettyFormat(convertedData, displays))
+		fmt.Fprint(output, result)
 	}
+	return nil
 }
 
-func getJsonFormat(data any) string {
-	result, err := json.MarshalIndent(data, "", "    ")
+// PrintDocuments prints every item as a separate YAML document. Protobuf
+// messages are encoded with protojson, so that field names match the ones
+// used in manifests.
+func PrintDocuments[T any](data []T) {
+	result, err := getYamlFormat(data)
 	if err != nil {
-		fmt.Printf("could not parse data: %v", err)
+		fmt.Fprintf(output, "could not parse data: %v\n", err)
+		return
 	}
-	return string(result)
+	fmt.Fprint(output, result)
 }
 
-func getPrettyFormat[T any](data []T, displays []Display) string {
-	format := getFormat(data, displays)
-	formatArguments := make([]any, 0, len(displays))
-	for _, display := range displays {
-		formatArguments = append(formatArguments, display.Display)
+func getYamlFormat[T any](data []T) (string, error) {
+	if len(data) == 0 {
+		return "", nil
 	}
 	var buffer strings.Builder
-	buffer.WriteString(fmt.Sprintf(format, formatArguments...))
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
 	for _, d := range data {
-		formatArguments = make([]any, 0, len(displays))
-		for _, display := range displays {
-			res := stringValueOf(d, display.Name)
-			formatArguments = append(formatArguments, res)
+		node, err := toYamlNode(d)
+		if err != nil {
+			return "", err
 		}
-		buffer.WriteString(fmt.Sprintf(format, formatArguments...))
+		if err := encoder.Encode(node); err != nil {
+			return "", err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// toYamlNode converts the value to a YAML node through its JSON encoding,
+// which keeps the order of fields.
+func toYamlNode(d any) (*yaml.Node, error) {
+	b, err := toJSON(d)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	root := node.Content[0]
+	clearStyle(root)
+	return root, nil
+}
+
+// clearStyle switches the flow style of the parsed JSON to block style.
+// Scalars which would otherwise change their type are still quoted by the
+// encoder.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		clearStyle(n)
 	}
-	return buffer.String()
 }
 
-func getFormat[T any](data []T, displays []Display) string {
-	longestFields := make([]int, len(displays))
-	for i, display := range displays {
-		longestFields[i] = minimumSpace + len(display.Display)
-		for _, d := range data {
-			res := stringValueOf(d, display.Name)
-			longestFields[i] = max(longestFields[i], len(res)+minimumSpace)
+// PrintJSON prints a single value as indented JSON.
+func PrintJSON(d any) {
+	value, err := toJSON(d)
+	if err == nil {
+		value, err = indentJSON(value)
+	}
+	if err != nil {
+		fmt.Fprintf(output, "could not parse data: %v\n", err)
+		return
+	}
+	fmt.Fprintln(output, string(value))
+}
+
+func getJsonFormat[T any](data []T) string {
+	values := make([]json.RawMessage, 0, len(data))
+	for _, d := range data {
+		value, err := toJSON(d)
+		if err != nil {
+			return fmt.Sprintf("could not parse data: %v", err)
 		}
+		values = append(values, value)
+	}
+	result, err := indentJSON(values)
+	if err != nil {
+		return fmt.Sprintf("could not parse data: %v", err)
 	}
-	var formatBuffer strings.Builder
-	for i := 0; i < len(longestFields)-1; i++ {
-		formatBuffer.WriteString(fmt.Sprintf("%%-%ds", longestFields[i]))
+	return string(result)
+}
+
+// toJSON encodes protobuf messages with protojson, which uses the field
+// names of the manifests and the names of enum values, and other values
+// with encoding/json.
+func toJSON(d any) (json.RawMessage, error) {
+	if m, ok := d.(proto.Message); ok {
+		return protojson.Marshal(m)
 	}
-	formatBuffer.WriteString("%s\n")
-	return formatBuffer.String()
+	return json.Marshal(d)
+}
+
+func indentJSON(value any) ([]byte, error) {
+	return json.MarshalIndent(value, "", "    ")
+}
+
+func getPrettyFormat[T any](data []T, displays []Display) string {
+	headers, rows := tableRows(data, displays)
+	return renderTable(headers, rows, columnColors(displays))
+}
+
+// tableRows returns the headers and the values of the columns of every
+// item.
+func tableRows[T any](data []T, displays []Display) ([]string, [][]string) {
+	headers := make([]string, 0, len(displays))
+	for _, display := range displays {
+		headers = append(headers, display.Display)
+	}
+	rows := make([][]string, 0, len(data))
+	for _, d := range data {
+		row := make([]string, 0, len(displays))
+		for _, display := range displays {
+			row = append(row, stringValueOf(d, display.Name))
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
 }
 
 func stringValueOf(d any, fieldName string) string {
@@ -103,31 +281,56 @@ func stringValueOf(d any, fieldName string) string {
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-	val := v.FieldByName(fieldName)
+	return formatReflectValue(v.FieldByName(fieldName))
+}
 
+// formatReflectValue formats the value of a column. Maps are printed as
+// key=value pairs sorted by key and slices as their elements, both
+// separated by commas.
+func formatReflectValue(val reflect.Value) string {
 	switch val.Kind() {
-	case reflect.Ptr:
-		if val.IsZero() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
 			return "-"
 		}
-		val = val.Elem()
-	case reflect.Int:
-		return strconv.FormatInt(val.Int(), 10)
-	case reflect.Int64:
+	}
+	if val.CanInterface() {
+		if stringer, ok := val.Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return formatReflectValue(val.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64)
 	case reflect.Bool:
 		return strconv.FormatBool(val.Bool())
 	case reflect.Map:
-		str := ""
-		if val.Len() > 0 {
-			keys := val.MapKeys()
-			for _, key := range keys {
-				value := val.MapIndex(key)
-				str += fmt.Sprintf(key.String() + "=" + value.String() + ",")
-			}
-			return str[:len(str)-1]
+		pairs := make([]string, 0, val.Len())
+		for _, key := range val.MapKeys() {
+			pairs = append(pairs, formatReflectValue(key)+"="+formatReflectValue(val.MapIndex(key)))
 		}
-		return str
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			elements = append(elements, formatReflectValue(val.Index(i)))
+		}
+		return strings.Join(elements, ",")
+	case reflect.String:
+		return val.String()
+	}
+	if val.CanInterface() {
+		return fmt.Sprint(val.Interface())
 	}
 	return val.String()
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/typepb"
 )
 
 func TestPrettyPrint(t *testing.T) {
@@ -64,12 +65,12 @@ func TestPrettyPrint(t *testing.T) {
 		},
 	}
 	names := []Display{
-		{"One", "A"},
-		{"Two", "B"},
-		{"Three", "C"},
-		{"Six", "E"},
-		{"Four", "D"},
-		{"Seven", "F"},
+		{Name: "One", Display: "A"},
+		{Name: "Two", Display: "B"},
+		{Name: "Three", Display: "C"},
+		{Name: "Six", Display: "E"},
+		{Name: "Four", Display: "D"},
+		{Name: "Seven", Display: "F"},
 	}
 	expected := `A         B       C     E                 D     F
 a         ab      abc   123456789012345   1     true
@@ -81,9 +82,9 @@ abc       abcde   ab    123456789012345   123   true
 	require.Equal(t, expected, result)
 
 	names = []Display{
-		{"One", "A"},
-		{"Two", "LONG_NAME"},
-		{"Three", "C"},
+		{Name: "One", Display: "A"},
+		{Name: "Two", Display: "LONG_NAME"},
+		{Name: "Three", Display: "C"},
 	}
 	data = append(data, Data{"abcdefghij", "a", nil, 3, "ignored", &num, true})
 	expected = `A            LONG_NAME   C
@@ -96,3 +97,47 @@ abcdefghij   a           -
 	result = getPrettyFormat(data, names)
 	require.Equal(t, expected, result)
 }
+
+func TestYamlFormat(t *testing.T) {
+	type Data struct {
+		Name   string            `json:"name"`
+		ID     string            `json:"id"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+	data := []Data{
+		{Name: "first", ID: "123", Labels: map[string]string{"enabled": "true"}},
+		{Name: "second: value", ID: "a"},
+	}
+	expected := `name: first
+id: "123"
+labels:
+  enabled: "true"
+---
+name: 'second: value'
+id: a
+`
+
+	result, err := getYamlFormat(data)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+
+	result, err = getYamlFormat([]Data{})
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestJsonFormat(t *testing.T) {
+	data := []*typepb.Field{
+		{Kind: typepb.Field_TYPE_STRING, Name: "display_name", JsonName: "displayName"},
+	}
+	expected := `[
+    {
+        "kind": "TYPE_STRING",
+        "name": "display_name",
+        "jsonName": "displayName"
+    }
+]`
+
+	require.Equal(t, expected, getJsonFormat(data))
+	require.Equal(t, "[]", getJsonFormat([]*typepb.Field{}))
+}
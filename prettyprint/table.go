@@ -0,0 +1,250 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// minimumColumnWidth is the width below which columns are not
+	// truncated to fit the table in the terminal.
+	minimumColumnWidth = 5
+	ellipsis           = "…"
+)
+
+// TableOptions control how tables are rendered.
+type TableOptions struct {
+	// NoHeaders omits the header row.
+	NoHeaders bool
+	// SortBy is the header of the column the rows are sorted by.
+	SortBy string
+	// Width is the maximum width of table rows, usually the width of the
+	// terminal. Zero means no limit.
+	Width int
+	// Wrap wraps values which do not fit in their column over several
+	// lines instead of truncating them.
+	Wrap bool
+	// Color enables the colors of the columns.
+	Color bool
+}
+
+var tableOptions TableOptions
+
+// SetTableOptions sets the options used to render tables.
+func SetTableOptions(options TableOptions) {
+	tableOptions = options
+}
+
+// visibleDisplays returns the columns printed in the table, which include
+// the wide columns only in wide output.
+func visibleDisplays(displays []Display, wide bool) []Display {
+	visible := make([]Display, 0, len(displays))
+	for _, display := range displays {
+		if wide || !display.Wide {
+			visible = append(visible, display)
+		}
+	}
+	return visible
+}
+
+// sortRows sorts the rows by the column set in the table options. Values
+// which are all numbers are compared as numbers and values which are all
+// durations, like ages and relative timestamps, as ages, the youngest
+// first.
+func sortRows(headers []string, rows [][]string) error {
+	if tableOptions.SortBy == "" {
+		return nil
+	}
+	column := -1
+	for i, header := range headers {
+		if strings.EqualFold(header, tableOptions.SortBy) {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return fmt.Errorf("cannot sort by unknown column %q, columns are %s", tableOptions.SortBy, strings.Join(headers, ", "))
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return lessValue(rows[i][column], rows[j][column])
+	})
+	return nil
+}
+
+func lessValue(a, b string) bool {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil {
+		return x < y
+	}
+	d, okD := parseDuration(a)
+	e, okE := parseDuration(b)
+	if okD && okE {
+		return d < e
+	}
+	return a < b
+}
+
+// renderTable aligns the columns of the rows under the headers. Columns
+// are narrowed to fit the width set in the table options, truncating or
+// wrapping the values which do not fit. Values of the columns with a color
+// function are colored if enabled in the table options.
+func renderTable(headers []string, rows [][]string, colors []func(string) string) string {
+	widths := columnWidths(headers, rows)
+	fitWidths(widths, headers, tableOptions.Width)
+	var buffer strings.Builder
+	if !tableOptions.NoHeaders {
+		writeTableRow(&buffer, headers, widths, nil)
+	}
+	if !tableOptions.Color {
+		colors = nil
+	}
+	for _, row := range rows {
+		writeTableRow(&buffer, row, widths, colors)
+	}
+	return buffer.String()
+}
+
+// columnColors returns the color functions of the columns.
+func columnColors(displays []Display) []func(string) string {
+	colors := make([]func(string) string, len(displays))
+	for i, display := range displays {
+		colors[i] = display.Color
+	}
+	return colors
+}
+
+// StatusColor returns the color of the status of AWI resources: green for
+// SUCCESS, yellow for IN_PROGRESS and red for FAILED.
+func StatusColor(status string) string {
+	switch status {
+	case "SUCCESS":
+		return colorAdded
+	case "IN_PROGRESS":
+		return colorChange
+	case "FAILED":
+		return colorDelete
+	}
+	return ""
+}
+
+func columnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+		for _, row := range rows {
+			widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+		}
+	}
+	return widths
+}
+
+// fitWidths narrows the widest columns until the rows fit in the width.
+// Columns are not narrowed below their header or minimumColumnWidth.
+func fitWidths(widths []int, headers []string, width int) {
+	if width <= 0 || len(widths) == 0 {
+		return
+	}
+	excess := (len(widths)-1)*minimumSpace - width
+	for _, w := range widths {
+		excess += w
+	}
+	for ; excess > 0; excess-- {
+		widest := -1
+		for i, w := range widths {
+			if w > max(utf8.RuneCountInString(headers[i]), minimumColumnWidth) && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+	}
+}
+
+// writeTableRow writes the row, as several lines if its values are
+// wrapped.
+func writeTableRow(buffer *strings.Builder, values []string, widths []int, colors []func(string) string) {
+	cells := make([][]string, len(values))
+	lines := 1
+	for i, value := range values {
+		cells[i] = fitValue(value, widths[i])
+		lines = max(lines, len(cells[i]))
+	}
+	for line := 0; line < lines; line++ {
+		var row strings.Builder
+		for i, cell := range cells {
+			text := ""
+			if line < len(cell) {
+				text = cell[line]
+			}
+			if i < len(colors) && colors[i] != nil && text != "" {
+				if color := colors[i](values[i]); color != "" {
+					row.WriteString(color + text + colorReset)
+				} else {
+					row.WriteString(text)
+				}
+			} else {
+				row.WriteString(text)
+			}
+			if i < len(cells)-1 {
+				row.WriteString(strings.Repeat(" ", widths[i]+minimumSpace-utf8.RuneCountInString(text)))
+			}
+		}
+		buffer.WriteString(strings.TrimRight(row.String(), " "))
+		buffer.WriteString("\n")
+	}
+}
+
+// fitValue returns the lines of the value in a column of the width.
+func fitValue(value string, width int) []string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return []string{value}
+	}
+	if !tableOptions.Wrap {
+		return []string{string(runes[:width-1]) + ellipsis}
+	}
+	var lines []string
+	for len(runes) > width {
+		end := width
+		// Prefer breaking after a separator, like the commas between
+		// labels.
+		if i := lastBreak(runes[:width]); i > 0 {
+			end = i + 1
+		}
+		lines = append(lines, string(runes[:end]))
+		runes = runes[end:]
+	}
+	return append(lines, string(runes))
+}
+
+func lastBreak(runes []rune) int {
+	for i := len(runes) - 1; i > 0; i-- {
+		if runes[i] == ',' || runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
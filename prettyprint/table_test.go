@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tableData struct {
+	Name   string
+	Size   int
+	Labels map[string]string
+	Zones  []string
+}
+
+var (
+	tableItems = []tableData{
+		{Name: "web", Size: 10, Labels: map[string]string{"tier": "front", "env": "prod", "app": "shop"}, Zones: []string{"a", "b"}},
+		{Name: "db", Size: 9, Labels: map[string]string{"env": "prod"}},
+	}
+	tableDisplays = []Display{
+		{Name: "Name", Display: "NAME"},
+		{Name: "Size", Display: "SIZE"},
+		{Name: "Zones", Display: "ZONES", Wide: true},
+		{Name: "Labels", Display: "LABELS"},
+	}
+)
+
+func TestTableRendering(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  TableOptions
+		wide     bool
+		expected string
+	}{
+		{
+			name: "sorted labels",
+			expected: `NAME   SIZE   LABELS
+web    10     app=shop,env=prod,tier=front
+db     9      env=prod
+`,
+		},
+		{
+			name: "wide",
+			wide: true,
+			expected: `NAME   SIZE   ZONES   LABELS
+web    10     a,b     app=shop,env=prod,tier=front
+db     9              env=prod
+`,
+		},
+		{
+			name:    "no headers sorted by size",
+			options: TableOptions{NoHeaders: true, SortBy: "size"},
+			expected: `db     9      env=prod
+web    10     app=shop,env=prod,tier=front
+`,
+		},
+		{
+			name:    "truncated",
+			options: TableOptions{Width: 30},
+			expected: `NAME   SIZE   LABELS
+web    10     app=shop,env=pr…
+db     9      env=prod
+`,
+		},
+		{
+			name:    "wrapped",
+			options: TableOptions{Width: 30, Wrap: true},
+			expected: `NAME   SIZE   LABELS
+web    10     app=shop,
+              env=prod,
+              tier=front
+db     9      env=prod
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTableOptions(tt.options)
+			defer SetTableOptions(TableOptions{})
+			headers, rows := tableRows(tableItems, visibleDisplays(tableDisplays, tt.wide))
+			require.NoError(t, sortRows(headers, rows))
+			require.Equal(t, tt.expected, renderTable(headers, rows, nil))
+		})
+	}
+
+	SetTableOptions(TableOptions{SortBy: "missing"})
+	defer SetTableOptions(TableOptions{})
+	headers, rows := tableRows(tableItems, tableDisplays)
+	require.EqualError(t, sortRows(headers, rows), `cannot sort by unknown column "missing", columns are NAME, SIZE, ZONES, LABELS`)
+}
+
+func TestStatusColor(t *testing.T) {
+	SetTableOptions(TableOptions{Color: true})
+	defer SetTableOptions(TableOptions{})
+	displays := []Display{
+		{Name: "Name", Display: "NAME"},
+		{Name: "Status", Display: "STATUS", Color: StatusColor},
+		{Name: "Age", Display: "AGE"},
+	}
+	data := []struct{ Name, Status, Age string }{
+		{Name: "first", Status: "SUCCESS", Age: "1m"},
+		{Name: "second", Status: "FAILED", Age: "2m"},
+		{Name: "third", Status: "WATCHING", Age: "3m"},
+	}
+	expected := "NAME     STATUS     AGE\n" +
+		"first    \033[32mSUCCESS\033[0m    1m\n" +
+		"second   \033[31mFAILED\033[0m     2m\n" +
+		"third    WATCHING   3m\n"
+
+	require.Equal(t, expected, getPrettyFormat(data, displays))
+}
+
+func TestSortRowsByAge(t *testing.T) {
+	// Ages and relative timestamps of the same data sort the same way, the
+	// youngest first.
+	expected := []string{"b", "e", "a", "d", "c"}
+	tests := []struct {
+		name   string
+		sortBy string
+		rows   [][]string
+	}{
+		{
+			name:   "ages",
+			sortBy: "AGE",
+			rows:   [][]string{{"a", "1m"}, {"b", "51s"}, {"c", "2d5h"}, {"d", "3h12m"}, {"e", "51s"}},
+		},
+		{
+			name:   "relative timestamps",
+			sortBy: "CREATE_TIME",
+			rows:   [][]string{{"a", "1m ago"}, {"b", "51s ago"}, {"c", "2d5h ago"}, {"d", "3h12m ago"}, {"e", "51s ago"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTableOptions(TableOptions{SortBy: tt.sortBy})
+			defer SetTableOptions(TableOptions{})
+			require.NoError(t, sortRows([]string{"NAME", tt.sortBy}, tt.rows))
+			names := make([]string, 0, len(tt.rows))
+			for _, row := range tt.rows {
+				names = append(names, row[0])
+			}
+			require.Equal(t, expected, names)
+		})
+	}
+	// Timestamps in the future are younger than the ones in the past.
+	require.True(t, lessValue("in 1h", "51s ago"))
+}
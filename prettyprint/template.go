@@ -0,0 +1,298 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	customColumnsFormat = "custom-columns"
+	jsonPathFormat      = "jsonpath"
+	goTemplateFormat    = "go-template"
+
+	noneValue = "<none>"
+)
+
+// splitFormat splits an output format like jsonpath={.id} into its name and
+// argument.
+func splitFormat(format string) (string, string) {
+	name, argument, _ := strings.Cut(format, "=")
+	return name, argument
+}
+
+// toGeneric returns the JSON representation of the value as maps, slices,
+// strings, json.Number and booleans, which templates are evaluated against.
+func toGeneric(d any) (any, error) {
+	b, err := toJSON(d)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func toGenericList[T any](data []T) ([]any, error) {
+	values := make([]any, 0, len(data))
+	for _, d := range data {
+		value, err := toGeneric(d)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// customColumn is a column of custom-columns output, like NAME:.metadata.name.
+type customColumn struct {
+	header string
+	path   *jsonPath
+}
+
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format requires columns, e.g. custom-columns=NAME:.metadata.name")
+	}
+	var columns []customColumn
+	for _, column := range strings.Split(spec, ",") {
+		header, expression, ok := strings.Cut(column, ":")
+		if !ok || header == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:.path", column)
+		}
+		expression = strings.TrimSuffix(strings.TrimPrefix(expression, "{"), "}")
+		path, err := parseJSONPath(expression)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, customColumn{header: header, path: path})
+	}
+	return columns, nil
+}
+
+func getCustomColumnsFormat(values []any, spec string) (string, error) {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return "", err
+	}
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.header)
+	}
+	rows := make([][]string, 0, len(values))
+	for _, value := range values {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, joinValues(column.path.evaluate(value)))
+		}
+		rows = append(rows, row)
+	}
+	if err := sortRows(headers, rows); err != nil {
+		return "", err
+	}
+	return renderTable(headers, rows, nil), nil
+}
+
+func joinValues(values []any) string {
+	if len(values) == 0 {
+		return noneValue
+	}
+	formatted := make([]string, 0, len(values))
+	for _, v := range values {
+		formatted = append(formatted, formatValue(v))
+	}
+	return strings.Join(formatted, ",")
+}
+
+// templateNode is a part of a JSONPath template: text printed as it is, a
+// path whose values are printed or a range over the values of a path.
+type templateNode struct {
+	text    string
+	path    *jsonPath
+	isRange bool
+	body    []templateNode
+}
+
+// parseJSONPathTemplate parses templates like {range [*]}{.id}{"\n"}{end}.
+func parseJSONPathTemplate(text string) ([]templateNode, error) {
+	nodes, rest, err := parseTemplateNodes(text, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected {end}")
+	}
+	return nodes, nil
+}
+
+func parseTemplateNodes(text string, inRange bool) ([]templateNode, string, error) {
+	var nodes []templateNode
+	for text != "" {
+		start := strings.Index(text, "{")
+		if start < 0 {
+			nodes = append(nodes, templateNode{text: text})
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, templateNode{text: text[:start]})
+		}
+		end := closingBrace(text[start:])
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated { in %q", text)
+		}
+		action := strings.TrimSpace(text[start+1 : start+end])
+		text = text[start+end+1:]
+		switch {
+		case action == "end":
+			if !inRange {
+				return nodes, "{end}" + text, nil
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parseJSONPath(strings.TrimPrefix(action, "range "))
+			if err != nil {
+				return nil, "", err
+			}
+			body, rest, err := parseTemplateNodes(text, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{path: path, isRange: true, body: body})
+			text = rest
+		case isQuoted(action):
+			literal, err := unquote(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{text: literal})
+		default:
+			path, err := parseJSONPath(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{path: path})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("missing {end}")
+	}
+	return nodes, "", nil
+}
+
+// closingBrace returns the index of the } closing the { at the beginning
+// of the text, skipping braces in quoted strings.
+func closingBrace(text string) int {
+	var quote byte
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(literal string) (string, error) {
+	if literal[0] == '\'' {
+		return literal[1 : len(literal)-1], nil
+	}
+	return strconv.Unquote(literal)
+}
+
+func executeJSONPathTemplate(buffer *strings.Builder, nodes []templateNode, value any) {
+	for _, node := range nodes {
+		switch {
+		case node.path == nil:
+			buffer.WriteString(node.text)
+		case node.isRange:
+			for _, v := range node.path.evaluate(value) {
+				executeJSONPathTemplate(buffer, node.body, v)
+			}
+		default:
+			values := node.path.evaluate(value)
+			for i, v := range values {
+				if i > 0 {
+					buffer.WriteString(" ")
+				}
+				buffer.WriteString(formatValue(v))
+			}
+		}
+	}
+}
+
+func getJSONPathFormat(value any, text string) (string, error) {
+	nodes, err := parseJSONPathTemplate(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid jsonpath template: %v", err)
+	}
+	var buffer strings.Builder
+	executeJSONPathTemplate(&buffer, nodes, value)
+	return buffer.String(), nil
+}
+
+func getGoTemplateFormat(value any, text string) (string, error) {
+	t, err := template.New("output").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid go-template: %v", err)
+	}
+	var buffer strings.Builder
+	if err := t.Execute(&buffer, value); err != nil {
+		return "", fmt.Errorf("could not execute go-template: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// getTemplateFormat renders the values with the custom-columns, jsonpath or
+// go-template format. Paths of custom columns are evaluated for every
+// item, while templates are evaluated against the whole value. The second
+// result is false for other formats.
+func getTemplateFormat(value any, items []any, format string) (string, bool, error) {
+	name, argument := splitFormat(format)
+	var result string
+	var err error
+	switch name {
+	case customColumnsFormat:
+		result, err = getCustomColumnsFormat(items, argument)
+	case jsonPathFormat:
+		result, err = getJSONPathFormat(value, argument)
+	case goTemplateFormat:
+		result, err = getGoTemplateFormat(value, argument)
+	default:
+		return "", false, nil
+	}
+	return result, true, err
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of timestamps in tables.
+const (
+	TimestampsRFC3339  = "rfc3339"
+	TimestampsRelative = "relative"
+	TimestampsLocal    = "local"
+
+	localTimestampLayout = "2006-01-02 15:04:05 MST"
+)
+
+// TimestampFormats lists the supported formats of timestamps.
+var TimestampFormats = []string{TimestampsRFC3339, TimestampsRelative, TimestampsLocal}
+
+// FormatTimestamp formats the RFC 3339 timestamp as it is, relative to now
+// like "3h12m ago" or in the time zone of now. Values which are not RFC
+// 3339 timestamps are returned unchanged.
+func FormatTimestamp(value, format string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	switch format {
+	case TimestampsRelative:
+		if t.After(now) {
+			return "in " + FormatDuration(t.Sub(now))
+		}
+		return FormatDuration(now.Sub(t)) + " ago"
+	case TimestampsLocal:
+		return t.In(now.Location()).Format(localTimestampLayout)
+	}
+	return value
+}
+
+// FormatAge returns the time elapsed between the RFC 3339 timestamp and
+// now, or "-" if the timestamp is not set or cannot be parsed.
+func FormatAge(value string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return "-"
+	}
+	return FormatDuration(now.Sub(t))
+}
+
+// FormatDuration formats the duration with its two most significant units,
+// like "45s", "12m", "3h12m" or "2d5h". Negative durations are printed as
+// zero.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d / time.Hour % 24)
+	minutes := int(d / time.Minute % 60)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", minutes)
+	case d < 24*time.Hour:
+		if minutes == 0 {
+			return fmt.Sprintf("%dh", hours)
+		}
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case days < 7 && hours > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// durationUnits are the units of durations printed by FormatDuration.
+var durationUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'h': time.Hour,
+	'm': time.Minute,
+	's': time.Second,
+}
+
+// parseDuration parses durations printed by FormatDuration and relative
+// timestamps printed by FormatTimestamp. Relative timestamps are returned
+// as the age of the timestamp, "3h12m ago" as 3h12m and "in 1h" as -1h, so
+// that they sort like ages, the youngest first.
+func parseDuration(value string) (time.Duration, bool) {
+	sign := time.Duration(1)
+	if rest, ok := strings.CutSuffix(value, " ago"); ok {
+		value = rest
+	} else if rest, ok := strings.CutPrefix(value, "in "); ok {
+		value, sign = rest, -1
+	}
+	if value == "" {
+		return 0, false
+	}
+	var d time.Duration
+	for value != "" {
+		end := strings.IndexAny(value, "dhms")
+		if end <= 0 {
+			return 0, false
+		}
+		n, err := strconv.Atoi(value[:end])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		d += time.Duration(n) * durationUnits[value[end]]
+		value = value[end+1:]
+	}
+	return sign * d, true
+}
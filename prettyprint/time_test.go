@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		-time.Second:                   "0s",
+		45 * time.Second:               "45s",
+		12*time.Minute + 5*time.Second: "12m",
+		3 * time.Hour:                  "3h",
+		3*time.Hour + 12*time.Minute:   "3h12m",
+		53*time.Hour + 10*time.Minute:  "2d5h",
+		48 * time.Hour:                 "2d",
+		30 * 24 * time.Hour:            "30d",
+	}
+	for d, expected := range tests {
+		require.Equal(t, expected, FormatDuration(d), d.String())
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	now := time.Date(2024, 3, 1, 15, 12, 0, 0, time.FixedZone("CET", 3600))
+	const created = "2024-03-01T11:00:00Z"
+
+	require.Equal(t, created, FormatTimestamp(created, TimestampsRFC3339, now))
+	require.Equal(t, "3h12m ago", FormatTimestamp(created, TimestampsRelative, now))
+	require.Equal(t, "in 1h", FormatTimestamp("2024-03-01T15:12:00Z", TimestampsRelative, now))
+	require.Equal(t, "2024-03-01 12:00:00 CET", FormatTimestamp(created, TimestampsLocal, now))
+	require.Equal(t, "yesterday", FormatTimestamp("yesterday", TimestampsLocal, now))
+
+	require.Equal(t, "3h12m", FormatAge(created, now))
+	require.Equal(t, "-", FormatAge("", now))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+	colorReset  = "\033[0m"
+	colorAdded  = "\033[32m"
+	colorChange = "\033[33m"
+	colorDelete = "\033[31m"
+)
+
+// Watcher renders the output of a command printed repeatedly. Table rows
+// which appeared or changed since the previous rendering are highlighted
+// and rows which disappeared are shown once as deleted. Rows are identified
+// by their first column.
+type Watcher struct {
+	Out io.Writer
+	// Redraw clears the screen and prints the whole table on every update.
+	// Otherwise only the rows which changed are printed.
+	Redraw bool
+	// Color highlights the rows with ANSI colors.
+	Color bool
+	// Ignore lists the headers of the columns left out when rows are
+	// compared, like ages, whose values change on every update.
+	Ignore []string
+
+	started  bool
+	previous string
+	rows     map[string]watchedRow
+	keys     []string
+}
+
+type watchedRow struct {
+	text     string
+	compared string
+}
+
+// Update renders the next output of the command. Output which is not a
+// table, like JSON, is printed again whenever it changes.
+func (w *Watcher) Update(text string, table bool) {
+	if !table {
+		if !w.started || text != w.previous {
+			if w.Redraw {
+				fmt.Fprint(w.Out, clearScreen)
+			}
+			fmt.Fprint(w.Out, text)
+		}
+		w.started, w.previous = true, text
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	header, lines := lines[0], lines[1:]
+	ignored := w.ignoredColumns(header)
+	rows := make(map[string]watchedRow, len(lines))
+	keys := make([]string, 0, len(lines))
+	occurrences := map[string]int{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		occurrences[fields[0]]++
+		key := fmt.Sprintf("%s#%d", fields[0], occurrences[fields[0]])
+		rows[key] = watchedRow{
+			text:     strings.Join(fields, " "),
+			compared: strings.Join(strings.Fields(blankColumns(line, ignored)), " "),
+		}
+		keys = append(keys, key)
+	}
+
+	if w.Redraw {
+		fmt.Fprint(w.Out, clearScreen)
+	}
+	if w.Redraw || !w.started {
+		fmt.Fprintln(w.Out, header)
+	}
+	for i, key := range keys {
+		previous, existed := w.rows[key]
+		switch {
+		case !w.started:
+			fmt.Fprintln(w.Out, lines[i])
+		case !existed:
+			w.printRow(lines[i], colorAdded)
+		case previous.compared != rows[key].compared:
+			w.printRow(lines[i], colorChange)
+		case w.Redraw:
+			fmt.Fprintln(w.Out, lines[i])
+		}
+	}
+	for _, key := range w.keys {
+		if _, ok := rows[key]; !ok {
+			w.printRow("deleted: "+w.rows[key].text, colorDelete)
+		}
+	}
+	w.started, w.rows, w.keys = true, rows, keys
+}
+
+func (w *Watcher) printRow(row, color string) {
+	if w.Color {
+		fmt.Fprintln(w.Out, color+row+colorReset)
+		return
+	}
+	fmt.Fprintln(w.Out, row)
+}
+
+// column is the span of runes of a table column, with end -1 for the last
+// column.
+type column struct {
+	start, end int
+}
+
+// ignoredColumns returns the spans of the ignored columns, which are
+// aligned under their headers.
+func (w *Watcher) ignoredColumns(header string) []column {
+	if len(w.Ignore) == 0 {
+		return nil
+	}
+	var starts []int
+	var names []string
+	runes := []rune(header)
+	for i, r := range runes {
+		if r != ' ' && (i == 0 || runes[i-1] == ' ') {
+			starts = append(starts, i)
+			names = append(names, "")
+		}
+		if r != ' ' {
+			names[len(names)-1] += string(r)
+		}
+	}
+	var columns []column
+	for i, name := range names {
+		for _, ignore := range w.Ignore {
+			if strings.EqualFold(name, ignore) {
+				end := -1
+				if i < len(starts)-1 {
+					end = starts[i+1]
+				}
+				columns = append(columns, column{start: starts[i], end: end})
+			}
+		}
+	}
+	return columns
+}
+
+// blankColumns replaces the values of the columns in the line with spaces.
+func blankColumns(line string, columns []column) string {
+	if len(columns) == 0 {
+		return line
+	}
+	runes := []rune(line)
+	for _, c := range columns {
+		end := c.end
+		if end < 0 || end > len(runes) {
+			end = len(runes)
+		}
+		for i := c.start; i < end; i++ {
+			runes[i] = ' '
+		}
+	}
+	return string(runes)
+}
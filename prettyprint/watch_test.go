@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Cisco Systems, Inc. and its affiliates
+// All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prettyprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	var out bytes.Buffer
+	w := &Watcher{Out: &out}
+
+	w.Update("ID   NAME   STATUS\n1    a      IN_PROGRESS\n2    b      SUCCESS\n\n", true)
+	require.Equal(t, "ID   NAME   STATUS\n1    a      IN_PROGRESS\n2    b      SUCCESS\n", out.String())
+
+	out.Reset()
+	w.Update("ID   NAME   STATUS\n1    a      IN_PROGRESS\n2    b      SUCCESS\n\n", true)
+	require.Empty(t, out.String())
+
+	out.Reset()
+	w.Update("ID   NAME   STATUS\n1    a      SUCCESS\n3    c      IN_PROGRESS\n\n", true)
+	require.Equal(t, "1    a      SUCCESS\n3    c      IN_PROGRESS\ndeleted: 2 b SUCCESS\n", out.String())
+
+	out.Reset()
+	w.Redraw, w.Color = true, true
+	w.Update("ID   NAME   STATUS\n1    a      SUCCESS\n3    c      SUCCESS\n\n", true)
+	require.Equal(t, clearScreen+"ID   NAME   STATUS\n1    a      SUCCESS\n"+colorChange+"3    c      SUCCESS"+colorReset+"\n", out.String())
+
+	out.Reset()
+	json := &Watcher{Out: &out}
+	json.Update("[]\n", false)
+	json.Update("[]\n", false)
+	json.Update("[1]\n", false)
+	require.Equal(t, "[]\n[1]\n", out.String())
+}
+
+func TestWatcherIgnore(t *testing.T) {
+	var out bytes.Buffer
+	w := &Watcher{Out: &out, Color: true, Ignore: []string{"AGE", "MOD_TIME"}}
+
+	w.Update("ID   MOD_TIME   STATUS    AGE\n1    51s ago    SUCCESS   51s\n", true)
+	out.Reset()
+	w.Update("ID   MOD_TIME   STATUS    AGE\n1    1m ago     SUCCESS   1m\n", true)
+	require.Empty(t, out.String())
+
+	w.Update("ID   MOD_TIME   STATUS    AGE\n1    1m ago     FAILED    1m\n", true)
+	require.Equal(t, colorChange+"1    1m ago     FAILED    1m"+colorReset+"\n", out.String())
+}